@@ -0,0 +1,12 @@
+# Look up a user group managed outside of this configuration by its exact name
+data "jumpcloud_usergroup" "engineering" {
+  name = "Engineering"
+}
+
+resource "jumpcloud_usergroup_membership" "engineering" {
+  group_id = data.jumpcloud_usergroup.engineering.id
+
+  users = [
+    jumpcloud_user.example.id,
+  ]
+}
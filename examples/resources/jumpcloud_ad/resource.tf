@@ -1,3 +1,6 @@
 resource "jumpcloud_ad" "example" {
-  domain = "DC=example,DC=com"
+  domain           = "DC=example,DC=com"
+  use_case         = "TWOWAYSYNC"
+  delegation_state = "ENABLED"
+  groups_enabled   = true
 }
\ No newline at end of file
@@ -0,0 +1,2 @@
+# Associations are imported using from_type/from_id/to_type/to_id
+terraform import jumpcloud_association.example user_group/63a1b2c3d4e5f6a7b8c9d0e1/system_group/63a1b2c3d4e5f6a7b8c9d0e2
@@ -0,0 +1,12 @@
+# Give the members of a user group access to every device in a device group
+resource "jumpcloud_association" "example" {
+  from_type = "user_group"
+  from_id   = jumpcloud_usergroup.example.id
+  to_type   = "system_group"
+  to_id     = jumpcloud_devicegroup.example.id
+
+  sudo = {
+    enabled      = true
+    passwordless = false
+  }
+}
@@ -0,0 +1,5 @@
+# Device groups can be imported by id
+terraform import jumpcloud_devicegroup.example 63a1b2c3d4e5f6a7b8c9d0e1
+
+# or by their exact name
+terraform import jumpcloud_devicegroup.example "macOS Devices"
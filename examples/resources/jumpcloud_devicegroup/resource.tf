@@ -1,3 +1,28 @@
 resource "jumpcloud_devicegroup" "example" {
   name = "example"
+}
+
+resource "jumpcloud_devicegroup" "macs" {
+  name              = "macOS Devices"
+  description       = "Every macOS device with disk encryption"
+  membership_method = "DYNAMIC_AUTOMATED"
+
+  member_query = {
+    filters = [
+      {
+        field    = "os"
+        operator = "eq"
+        value    = "Mac OS X"
+      },
+      {
+        field    = "fde.active"
+        operator = "eq"
+        value    = "true"
+      },
+    ]
+  }
+
+  attributes = {
+    team = "it"
+  }
 }
\ No newline at end of file
@@ -0,0 +1,2 @@
+# Device group memberships are imported by the id of the device group
+terraform import jumpcloud_devicegroup_membership.example 63a1b2c3d4e5f6a7b8c9d0e1
@@ -0,0 +1,10 @@
+resource "jumpcloud_devicegroup_membership" "example" {
+  group_id = jumpcloud_devicegroup.example.id
+
+  systems = [
+    "63a1b2c3d4e5f6a7b8c9d0e1",
+  ]
+
+  # Leave members added outside of Terraform alone
+  authoritative = false
+}
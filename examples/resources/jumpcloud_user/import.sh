@@ -0,0 +1,5 @@
+# Users can be imported by id
+terraform import jumpcloud_user.example 63a1b2c3d4e5f6a7b8c9d0e1
+
+# or by username
+terraform import jumpcloud_user.example jdoe
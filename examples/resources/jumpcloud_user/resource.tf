@@ -0,0 +1,30 @@
+resource "jumpcloud_user" "example" {
+  username    = "jdoe"
+  email       = "jdoe@example.com"
+  firstname   = "Jane"
+  lastname    = "Doe"
+  displayname = "Jane Doe"
+
+  sudo = {
+    enabled      = false
+    passwordless = false
+  }
+
+  mfa = {
+    enabled = true
+  }
+
+  locked    = false
+  suspended = false
+
+  employee = {
+    identifier = "E-1234"
+    type       = "Full Time"
+    job_title  = "Engineer"
+    department = "Engineering"
+  }
+
+  attributes = {
+    team = "platform"
+  }
+}
@@ -28,18 +28,35 @@ resource "jumpcloud_usergroup" "example" {
     }
   ]
 
-  samba = false
-
-  member_queries = [
+  properties = [
     {
-      query = {
-        field    = "example"
-        operator = "eq"
-        value    = "example"
-      }
+      name  = "costCenter"
+      value = "1234"
     }
   ]
 
+  samba = false
+
+  member_query = {
+    type    = "SearchQuery"
+    combine = "or"
+
+    filters = [
+      {
+        field    = "department"
+        operator = "in"
+        value    = "Engineering|Security"
+      },
+      {
+        field    = "jobTitle"
+        operator = "eq"
+        value    = "CTO"
+      },
+    ]
+
+    exclude = ["63a1b2c3d4e5f6a7b8c9d0e1"]
+  }
+
   notify = false
   auto   = false
 }
\ No newline at end of file
@@ -0,0 +1,2 @@
+# User group memberships are imported by the id of the user group
+terraform import jumpcloud_usergroup_membership.example 63a1b2c3d4e5f6a7b8c9d0e1
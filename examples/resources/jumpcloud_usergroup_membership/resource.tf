@@ -0,0 +1,7 @@
+resource "jumpcloud_usergroup_membership" "example" {
+  group_id = jumpcloud_usergroup.example.id
+
+  users = [
+    jumpcloud_user.example.id,
+  ]
+}
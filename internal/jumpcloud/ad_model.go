@@ -0,0 +1,15 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ActiveDirectoryResourceModel struct {
+	Domain          types.String `tfsdk:"domain"`
+	Id              types.String `tfsdk:"id"`
+	UseCase         types.String `tfsdk:"use_case"`
+	PrimaryAgent    types.String `tfsdk:"primary_agent"`
+	DelegationState types.String `tfsdk:"delegation_state"`
+	GroupsEnabled   types.Bool   `tfsdk:"groups_enabled"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/api"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -12,10 +13,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	"github.com/davecgh/go-spew/spew"
-
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -30,12 +27,7 @@ func NewActiveDirectoryResource() resource.Resource {
 }
 
 type ActiveDirectoryResource struct {
-	api *api.JumpCloudClientApiV2
-}
-
-type ActiveDirectoryResourceModel struct {
-	Domain types.String `tfsdk:"domain"`
-	Id     types.String `tfsdk:"id"`
+	api *apiclient.Client
 }
 
 func (r *ActiveDirectoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,26 +35,7 @@ func (r *ActiveDirectoryResource) Metadata(ctx context.Context, req resource.Met
 }
 
 func (r *ActiveDirectoryResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		MarkdownDescription: "Active Directory",
-		Version:             0,
-
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Computed:            true,
-				MarkdownDescription: "Resource ID (Computed / Read-Only)",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					resource.UseStateForUnknown(),
-				},
-				Type: types.StringType,
-			},
-			"domain": {
-				MarkdownDescription: "The Active Directory Domain (eg DC=mydomain,DC=com}",
-				Required:            true,
-				Type:                types.StringType,
-			},
-		},
-	}, nil
+	return ActiveDirectorySchema, nil
 }
 
 func (r *ActiveDirectoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -81,7 +54,7 @@ func (r *ActiveDirectoryResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	r.api = &api.V2
+	r.api = &api.Internal
 }
 
 func (r *ActiveDirectoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -93,36 +66,27 @@ func (r *ActiveDirectoryResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	var domain = plan.Domain.ValueString()
-
-	var options = make(map[string]interface{})
-	options["body"] = jcapiv2.ActiveDirectoryInput{
-		Domain: domain,
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("Calling ActiveDirectoriesPost with\n%s", spew.Sdump(options)))
+	ctx, cancel := timeouts.WithTimeout(ctx, plan.Timeouts, timeouts.CREATE)
+	defer cancel()
 
-	ad, response, error := r.api.Client.ActiveDirectoryApi.ActivedirectoriesPost(r.api.Auth, api.API_ACCEPT_TYPE, api.API_CONTENT_TYPE, options)
+	ad, _, error := r.api.CreateActiveDirectory(ctx, convertResourceToActiveDirectory(plan))
 
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Active Directory",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 		return
 	}
 
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
-	tflog.Info(ctx, fmt.Sprintf("Created new Active Directory\n%s", spew.Sdump(ad)))
+	tflog.Info(ctx, "Created new Active Directory", map[string]interface{}{
+		"id":     ad.Id,
+		"domain": ad.Domain,
+	})
 
-	plan.Id = types.StringValue(ad.Id)
-	plan.Domain = types.StringValue(ad.Domain)
+	convertActiveDirectoryToResource(plan, &ad)
 
-	var diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *ActiveDirectoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -133,21 +97,32 @@ func (r *ActiveDirectoryResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	ctx, cancel := timeouts.WithTimeout(ctx, state.Timeouts, timeouts.READ)
+	defer cancel()
+
 	tflog.Info(ctx, "Refreshing Active Directory State from JumpCloud")
 
-	ad, response, error := r.api.Client.ActiveDirectoryApi.ActivedirectoriesGet(r.api.Auth, state.Id.ValueString(), api.API_CONTENT_TYPE, api.API_ACCEPT_TYPE, nil)
+	ad, _, error := r.api.GetActiveDirectory(ctx, state.Id.ValueString())
+
+	if apiclient.IsNotFound(error) {
+		tflog.Warn(ctx, "Active Directory no longer exists on JumpCloud, removing it from state", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error retreiving Active Directory from JumpCloud",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
 
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
-
-	state.Domain = types.StringValue(ad.Domain)
+	convertActiveDirectoryToResource(&state, &ad)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -157,11 +132,32 @@ func (r *ActiveDirectoryResource) Read(ctx context.Context, req resource.ReadReq
 	}
 }
 
+// Update changes the integration settings in place, a new domain is planned as a
+// replacement so it never reaches Update
 func (r *ActiveDirectoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update is unsupported for Active Directories",
-		"Update is unsupported for Active Directories",
-	)
+	var plan ActiveDirectoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := timeouts.WithTimeout(ctx, plan.Timeouts, timeouts.UPDATE)
+	defer cancel()
+
+	ad, _, error := r.api.UpdateActiveDirectory(ctx, convertResourceToActiveDirectory(&plan))
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Active Directory on JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+
+		return
+	}
+
+	convertActiveDirectoryToResource(&plan, &ad)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *ActiveDirectoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -172,19 +168,54 @@ func (r *ActiveDirectoryResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	response, error := r.api.Client.ActiveDirectoryApi.ActivedirectoriesDelete(r.api.Auth, state.Id.ValueString(), api.API_CONTENT_TYPE, api.API_ACCEPT_TYPE, nil)
+	ctx, cancel := timeouts.WithTimeout(ctx, state.Timeouts, timeouts.DELETE)
+	defer cancel()
+
+	_, error := r.api.DeleteActiveDirectory(ctx, state.Id.ValueString())
+
+	// Already deleted outside of Terraform
+	if apiclient.IsNotFound(error) {
+		return
+	}
+
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Active Directory from JumpCloud",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
-
 }
 
 func (r *ActiveDirectoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func convertActiveDirectoryToResource(resourceModel *ActiveDirectoryResourceModel, apiModel *apiclient.ActiveDirectory) {
+	resourceModel.Id = types.StringValue(apiModel.Id)
+	resourceModel.Domain = types.StringValue(apiModel.Domain)
+	resourceModel.UseCase = types.StringValue(apiModel.UseCase)
+	resourceModel.PrimaryAgent = types.StringValue(apiModel.PrimaryAgent)
+	resourceModel.DelegationState = types.StringValue(apiModel.DelegationState)
+	resourceModel.GroupsEnabled = types.BoolValue(apiModel.GroupsEnabled != nil && *apiModel.GroupsEnabled)
+}
+
+// convertResourceToActiveDirectory only sets the settings that are known, so the
+// ones left unconfigured keep their value on JumpCloud
+func convertResourceToActiveDirectory(resourceModel *ActiveDirectoryResourceModel) *apiclient.ActiveDirectory {
+	apiModel := &apiclient.ActiveDirectory{
+		Id:              resourceModel.Id.ValueString(),
+		Domain:          resourceModel.Domain.ValueString(),
+		UseCase:         resourceModel.UseCase.ValueString(),
+		PrimaryAgent:    resourceModel.PrimaryAgent.ValueString(),
+		DelegationState: resourceModel.DelegationState.ValueString(),
+	}
+
+	if !resourceModel.GroupsEnabled.IsNull() && !resourceModel.GroupsEnabled.IsUnknown() {
+		enabled := resourceModel.GroupsEnabled.ValueBool()
+		apiModel.GroupsEnabled = &enabled
+	}
+
+	return apiModel
+}
@@ -2,7 +2,6 @@ package jumpcloud
 
 import (
 	"fmt"
-	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,8 +14,11 @@ resource "jumpcloud_ad" "test" {
 `
 
 func TestAccActiveDirectoryResource(t *testing.T) {
+	testAccRecorder(t)
+
 	test_env := GetTestEnv()
 	domain := fmt.Sprintf("DC=%s,DC=test,DC=com", test_env)
+	replacement := fmt.Sprintf("DC=%s,DC=update,DC=test,DC=com", test_env)
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -39,10 +41,32 @@ resource "jumpcloud_ad" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
-			// Update is not supported -- how do we test for expecting an error tho?
+			// Settings are updated in place
 			{
-				Config:      ProviderConfig() + `resource "jumpcloud_ad" "test" { domain = "DC=update,DC=test,DC=com" }`,
-				ExpectError: regexp.MustCompile(".*"),
+				Config: ProviderConfig() + `
+resource "jumpcloud_ad" "test" {
+	domain           = "` + domain + `"
+	use_case         = "TWOWAYSYNC"
+	delegation_state = "ENABLED"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_ad.test", "domain", domain),
+					resource.TestCheckResourceAttr("jumpcloud_ad.test", "use_case", "TWOWAYSYNC"),
+					resource.TestCheckResourceAttr("jumpcloud_ad.test", "delegation_state", "ENABLED"),
+				),
+			},
+			// A new domain replaces the integration
+			{
+				Config: ProviderConfig() + `
+resource "jumpcloud_ad" "test" {
+	domain = "` + replacement + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_ad.test", "domain", replacement),
+					resource.TestCheckResourceAttrSet("jumpcloud_ad.test", "id"),
+				),
 			},
 			// Delete Testing happens automatically
 		},
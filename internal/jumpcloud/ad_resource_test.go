@@ -0,0 +1,135 @@
+package jumpcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
+)
+
+func TestActiveDirectoryResourceCreateReadDelete(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewActiveDirectoryResource)
+
+	state, diags := createResource(t, r, ActiveDirectoryResourceModel{
+		Id:       types.StringUnknown(),
+		Domain:   types.StringValue("DC=example,DC=com"),
+		Timeouts: timeouts.Null(),
+	})
+	failOnDiagnostics(t, diags)
+
+	var created ActiveDirectoryResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	if _, ok := server.Get(fakeserver.ActiveDirectories, created.Id.ValueString()); !ok {
+		t.Fatalf("Expected active directory %s to exist on the server", created.Id.ValueString())
+	}
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var read ActiveDirectoryResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &read))
+
+	if read.Id != created.Id || read.Domain != created.Domain {
+		t.Errorf("Expected %v after refresh but got %v", created, read)
+	}
+
+	server.Remove(fakeserver.ActiveDirectories, created.Id.ValueString())
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	if !state.Raw.IsNull() {
+		t.Errorf("Expected the active directory to be removed from state")
+	}
+}
+
+func TestActiveDirectoryResourceDuplicateDomain(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	server.Seed(fakeserver.ActiveDirectories, fakeserver.Object{"domain": "DC=example,DC=com"})
+
+	r := newFakeResource(t, server, NewActiveDirectoryResource)
+
+	_, diags := createResource(t, r, ActiveDirectoryResourceModel{
+		Id:       types.StringUnknown(),
+		Domain:   types.StringValue("DC=example,DC=com"),
+		Timeouts: timeouts.Null(),
+	})
+
+	if !diags.HasError() {
+		t.Errorf("Expected an error when the domain already exists")
+	}
+}
+
+func TestActiveDirectoryResourceUpdateSettings(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewActiveDirectoryResource)
+
+	model := ActiveDirectoryResourceModel{
+		Id:            types.StringUnknown(),
+		Domain:        types.StringValue("DC=example,DC=com"),
+		UseCase:       types.StringValue(apiclient.AD_USE_CASE_AD_AUTHORITY),
+		PrimaryAgent:  types.StringUnknown(),
+		GroupsEnabled: types.BoolValue(true),
+		Timeouts:      timeouts.Null(),
+	}
+
+	state, diags := createResource(t, r, model)
+	failOnDiagnostics(t, diags)
+
+	var created ActiveDirectoryResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	// Settings managed outside of Terraform are left alone
+	server.Edit(fakeserver.ActiveDirectories, created.Id.ValueString(), fakeserver.Object{
+		"primaryAgent":    "agent-1",
+		"delegationState": apiclient.AD_DELEGATION_DISABLED,
+	})
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	model = created
+	model.UseCase = types.StringValue(apiclient.AD_USE_CASE_TWO_WAY_SYNC)
+	model.DelegationState = types.StringValue(apiclient.AD_DELEGATION_ENABLED)
+
+	state, diags = updateResource(t, r, state, model)
+	failOnDiagnostics(t, diags)
+
+	var updated ActiveDirectoryResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &updated))
+
+	if updated.Id != created.Id || updated.UseCase.ValueString() != apiclient.AD_USE_CASE_TWO_WAY_SYNC || updated.DelegationState.ValueString() != apiclient.AD_DELEGATION_ENABLED {
+		t.Errorf("Expected the Active Directory to be updated in place but got %v", updated)
+	}
+
+	ad, _ := server.Get(fakeserver.ActiveDirectories, created.Id.ValueString())
+
+	expected := fakeserver.Object{
+		"domain":          "DC=example,DC=com",
+		"useCase":         apiclient.AD_USE_CASE_TWO_WAY_SYNC,
+		"delegationState": apiclient.AD_DELEGATION_ENABLED,
+		"primaryAgent":    "agent-1",
+		"groupsEnabled":   true,
+	}
+
+	for field, value := range expected {
+		if ad[field] != value {
+			t.Errorf("Expected %s to be %v on the server but got %v", field, value, ad[field])
+		}
+	}
+}
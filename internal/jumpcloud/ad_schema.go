@@ -0,0 +1,86 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
+)
+
+var ActiveDirectorySchema = tfsdk.Schema{
+	MarkdownDescription: "Active Directory",
+	Version:             0,
+
+	Attributes: map[string]tfsdk.Attribute{
+		"id": {
+			Computed:            true,
+			MarkdownDescription: "Resource ID (Computed / Read-Only)",
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Type: types.StringType,
+		},
+		"domain": {
+			MarkdownDescription: "The Active Directory Domain (eg DC=mydomain,DC=com}. Changing the domain replaces the integration.",
+			Required:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.RequiresReplace(),
+			},
+			Type: types.StringType,
+		},
+		"use_case": {
+			MarkdownDescription: "Which directory is the authority for users and passwords: `ADASAUTHORITY` (Active Directory, synced to JumpCloud), `JCASAUTHORITY` (JumpCloud, synced to Active Directory), `TWOWAYSYNC` (changes flow both ways) or `UNSET`. Keeps the current setting when not configured.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Validators: []tfsdk.AttributeValidator{
+				stringvalidator.OneOf(
+					apiclient.AD_USE_CASE_UNSET,
+					apiclient.AD_USE_CASE_AD_AUTHORITY,
+					apiclient.AD_USE_CASE_JC_AUTHORITY,
+					apiclient.AD_USE_CASE_TWO_WAY_SYNC,
+				),
+			},
+			Type: types.StringType,
+		},
+		"primary_agent": {
+			MarkdownDescription: "ID of the AD agent that syncs this domain. Keeps the current agent when not configured.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Type: types.StringType,
+		},
+		"delegation_state": {
+			MarkdownDescription: "Whether JumpCloud delegates password authentication to the domain controllers (`ENABLED`) or authenticates users itself (`DISABLED`). Keeps the current setting when not configured.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Validators: []tfsdk.AttributeValidator{
+				stringvalidator.OneOf(
+					apiclient.AD_DELEGATION_ENABLED,
+					apiclient.AD_DELEGATION_DISABLED,
+				),
+			},
+			Type: types.StringType,
+		},
+		"groups_enabled": {
+			MarkdownDescription: "Whether group memberships are synced along with the users. Keeps the current setting when not configured.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Type: types.BoolType,
+		},
+		"timeouts": timeouts.Attribute(),
+	},
+}
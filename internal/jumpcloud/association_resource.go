@@ -0,0 +1,361 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                   = &AssociationResource{}
+	_ resource.ResourceWithConfigure      = &AssociationResource{}
+	_ resource.ResourceWithImportState    = &AssociationResource{}
+	_ resource.ResourceWithValidateConfig = &AssociationResource{}
+)
+
+func NewAssociationResource() resource.Resource {
+	return &AssociationResource{}
+}
+
+type AssociationResource struct {
+	api *apiclient.Client
+}
+
+type AssociationResourceModel struct {
+	Id       types.String     `tfsdk:"id"`
+	FromType types.String     `tfsdk:"from_type"`
+	FromId   types.String     `tfsdk:"from_id"`
+	ToType   types.String     `tfsdk:"to_type"`
+	ToId     types.String     `tfsdk:"to_id"`
+	Sudo     *SudoConfigModel `tfsdk:"sudo"`
+}
+
+func graphObjectTypes() []string {
+	objectTypes := make([]string, 0, len(apiclient.GraphTypeEndpoints))
+	for objectType := range apiclient.GraphTypeEndpoints {
+		objectTypes = append(objectTypes, objectType)
+	}
+
+	sort.Strings(objectTypes)
+
+	return objectTypes
+}
+
+func (r *AssociationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_association"
+}
+
+func (r *AssociationResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	objectTypes := graphObjectTypes()
+
+	return tfsdk.Schema{
+		MarkdownDescription: "Graph association between two JumpCloud objects, for example a User Group and an Application",
+		Version:             0,
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Resource ID in the form `from_type/from_id/to_type/to_id` (Computed / Read-Only)",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+			"from_type": {
+				MarkdownDescription: "Type of the object the association starts from. One of `" + strings.Join(objectTypes, "`, `") + "`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf(objectTypes...),
+				},
+			},
+			"from_id": {
+				MarkdownDescription: "ID of the object the association starts from",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"to_type": {
+				MarkdownDescription: "Type of the associated object. One of `" + strings.Join(objectTypes, "`, `") + "`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf(objectTypes...),
+				},
+			},
+			"to_id": {
+				MarkdownDescription: "ID of the associated object",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"sudo": {
+				MarkdownDescription: "Sudo configuration, only valid between a user or user group and a system or system group",
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"enabled": {
+						MarkdownDescription: "Whether the user(s) will be allowed to use sudo on the system(s)",
+						Type:                types.BoolType,
+						Required:            true,
+					},
+					"passwordless": {
+						MarkdownDescription: "Whether the user(s) will be able to use sudo without entering a password",
+						Type:                types.BoolType,
+						Required:            true,
+					},
+				}),
+				Optional: true,
+			},
+		},
+	}, nil
+}
+
+func (r *AssociationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AssociationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.FromType.IsUnknown() || config.FromType.IsNull() || config.ToType.IsUnknown() || config.ToType.IsNull() {
+		return
+	}
+
+	fromType := config.FromType.ValueString()
+	toType := config.ToType.ValueString()
+
+	if _, ok := apiclient.GraphTypeEndpoints[fromType]; !ok {
+		return
+	}
+
+	if !apiclient.IsGraphAssociationAllowed(fromType, toType) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("to_type"),
+			"Unsupported Association",
+			fmt.Sprintf("A %s cannot be associated with a %s. Supported targets for %s are: %s",
+				fromType, toType, fromType, strings.Join(apiclient.GraphAssociationTargets[fromType], ", ")),
+		)
+	}
+
+	if config.Sudo != nil && !isSudoAssociation(fromType, toType) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sudo"),
+			"Unsupported Association Attribute",
+			"sudo can only be set on associations between a user or user_group and a system or system_group",
+		)
+	}
+}
+
+func (r *AssociationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(JumpCloudApi)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.JumpCloudClientApi, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.api = &api.Internal
+}
+
+func (r *AssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *AssociationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, error := r.api.ModifyAssociation(ctx, plan.FromType.ValueString(), plan.FromId.ValueString(), convertResourceToGraphOperation(apiclient.GRAPH_OP_ADD, plan))
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Association",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(strings.Join([]string{
+		plan.FromType.ValueString(),
+		plan.FromId.ValueString(),
+		plan.ToType.ValueString(),
+		plan.ToId.ValueString(),
+	}, "/"))
+
+	tflog.Info(ctx, "Created new Association", map[string]interface{}{
+		"id": plan.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *AssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Refreshing Association State from JumpCloud")
+
+	var state *AssociationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connection, error := r.api.GetAssociation(ctx, state.FromType.ValueString(), state.FromId.ValueString(), state.ToType.ValueString(), state.ToId.ValueString())
+
+	// The object the association starts from was deleted, which removes its associations too
+	if apiclient.IsNotFound(error) {
+		connection, error = nil, nil
+	}
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retreiving Association from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	if connection == nil {
+		tflog.Warn(ctx, "Association no longer exists, removing from state", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if sudo, ok := connection.Attributes["sudo"].(map[string]interface{}); ok {
+		enabled, _ := sudo["enabled"].(bool)
+		passwordless, _ := sudo["withoutPassword"].(bool)
+
+		if state.Sudo != nil || enabled || passwordless {
+			state.Sudo = &SudoConfigModel{
+				Enabled:      types.BoolValue(enabled),
+				Passwordless: types.BoolValue(passwordless),
+			}
+		}
+	} else if state.Sudo != nil {
+		state.Sudo = &SudoConfigModel{
+			Enabled:      types.BoolValue(false),
+			Passwordless: types.BoolValue(false),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update only ever changes the association attributes, everything else requires replacement
+func (r *AssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *AssociationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, error := r.api.ModifyAssociation(ctx, plan.FromType.ValueString(), plan.FromId.ValueString(), convertResourceToGraphOperation(apiclient.GRAPH_OP_UPDATE, plan))
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Association on JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *AssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *AssociationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, error := r.api.ModifyAssociation(ctx, state.FromType.ValueString(), state.FromId.ValueString(), apiclient.GraphOperation{
+		Op:   apiclient.GRAPH_OP_REMOVE,
+		Type: state.ToType.ValueString(),
+		Id:   state.ToId.ValueString(),
+	})
+	if error != nil && !apiclient.IsNotFound(error) {
+		resp.Diagnostics.AddError(
+			"Error deleting Association from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+	}
+}
+
+// ImportState expects an id in the form from_type/from_id/to_type/to_id
+func (r *AssociationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: from_type/from_id/to_type/to_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("from_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("from_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("to_type"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("to_id"), parts[3])...)
+}
+
+func isSudoAssociation(fromType string, toType string) bool {
+	isUser := func(t string) bool { return t == "user" || t == "user_group" }
+	isSystem := func(t string) bool { return t == "system" || t == "system_group" }
+
+	return (isUser(fromType) && isSystem(toType)) || (isSystem(fromType) && isUser(toType))
+}
+
+func convertResourceToGraphOperation(op string, resourceModel *AssociationResourceModel) apiclient.GraphOperation {
+	operation := apiclient.GraphOperation{
+		Op:   op,
+		Type: resourceModel.ToType.ValueString(),
+		Id:   resourceModel.ToId.ValueString(),
+	}
+
+	if isSudoAssociation(resourceModel.FromType.ValueString(), resourceModel.ToType.ValueString()) {
+		var sudo apiclient.UserGroupSudoConfig
+		if resourceModel.Sudo != nil {
+			sudo.Enabled = resourceModel.Sudo.Enabled.ValueBool()
+			sudo.WithoutPassword = resourceModel.Sudo.Passwordless.ValueBool()
+		}
+
+		operation.Attributes = map[string]interface{}{
+			"sudo": sudo,
+		}
+	}
+
+	return operation
+}
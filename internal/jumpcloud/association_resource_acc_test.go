@@ -0,0 +1,71 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssociationResource(t *testing.T) {
+	testAccRecorder(t)
+
+	test_env := GetTestEnv()
+	name := fmt.Sprintf("terraform-test-association-%s", test_env)
+
+	config := func(sudo string) string {
+		return ProviderConfig() + `
+resource "jumpcloud_usergroup" "test" {
+	name = "` + name + `"
+}
+
+resource "jumpcloud_devicegroup" "test" {
+	name = "` + name + `"
+}
+
+resource "jumpcloud_association" "test" {
+	from_type = "user_group"
+	from_id   = jumpcloud_usergroup.test.id
+	to_type   = "system_group"
+	to_id     = jumpcloud_devicegroup.test.id
+	` + sudo + `
+}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig() + `
+resource "jumpcloud_association" "test" {
+	from_type = "user_group"
+	from_id   = "000000000000000000000000"
+	to_type   = "policy"
+	to_id     = "000000000000000000000000"
+}`,
+				ExpectError: regexp.MustCompile("Unsupported Association"),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("jumpcloud_association.test", "from_id", "jumpcloud_usergroup.test", "id"),
+					resource.TestCheckResourceAttrPair("jumpcloud_association.test", "to_id", "jumpcloud_devicegroup.test", "id"),
+					resource.TestCheckResourceAttrSet("jumpcloud_association.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_association.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(`sudo = { enabled = true, passwordless = false }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_association.test", "sudo.enabled", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_association.test", "sudo.passwordless", "false"),
+				),
+			},
+		},
+	})
+}
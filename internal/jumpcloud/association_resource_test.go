@@ -0,0 +1,151 @@
+package jumpcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+)
+
+func newAssociationModel(fromType string, fromId string, toType string, toId string) AssociationResourceModel {
+	return AssociationResourceModel{
+		Id:       types.StringUnknown(),
+		FromType: types.StringValue(fromType),
+		FromId:   types.StringValue(fromId),
+		ToType:   types.StringValue(toType),
+		ToId:     types.StringValue(toId),
+	}
+}
+
+func newSudoConfig(enabled bool, passwordless bool) *SudoConfigModel {
+	return &SudoConfigModel{
+		Enabled:      types.BoolValue(enabled),
+		Passwordless: types.BoolValue(passwordless),
+	}
+}
+
+func TestAssociationResourceValidateConfig(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	r := newFakeResource(t, server, NewAssociationResource)
+
+	withSudo := func(model AssociationResourceModel) AssociationResourceModel {
+		model.Sudo = newSudoConfig(true, false)
+		return model
+	}
+
+	// An empty errorPath expects the configuration to be valid
+	tests := map[string]struct {
+		model     AssociationResourceModel
+		errorPath path.Path
+	}{
+		"supported": {
+			model: newAssociationModel("user_group", "a", "application", "b"),
+		},
+		"sudo on a system group": {
+			model: withSudo(newAssociationModel("user_group", "a", "system_group", "b")),
+		},
+		"sudo from a system": {
+			model: withSudo(newAssociationModel("system", "a", "user", "b")),
+		},
+		"unsupported target": {
+			model:     newAssociationModel("user_group", "a", "policy", "b"),
+			errorPath: path.Root("to_type"),
+		},
+		"sudo on an application": {
+			model:     withSudo(newAssociationModel("user_group", "a", "application", "b")),
+			errorPath: path.Root("sudo"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := validateConfig(t, r, test.model)
+
+			if len(test.errorPath.Steps()) == 0 {
+				failOnDiagnostics(t, diags)
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("Expected one error but got %v", diags)
+			}
+
+			if withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(test.errorPath) {
+				t.Errorf("Expected the error on %s but got %v", test.errorPath, diags.Errors()[0])
+			}
+		})
+	}
+}
+
+func TestAssociationResourceSudo(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewAssociationResource)
+
+	groupId := server.Seed(fakeserver.UserGroups, fakeserver.Object{"name": "admins"})["id"].(string)
+	systemGroupId := server.Seed(fakeserver.SystemGroups, fakeserver.Object{"name": "servers"})["id"].(string)
+
+	sudoOnServer := func() map[string]interface{} {
+		t.Helper()
+
+		attributes, ok := server.Association(fakeserver.UserGroups, groupId, systemGroupId)
+		if !ok {
+			t.Fatalf("Expected the user group to be associated with the system group")
+		}
+
+		sudo, _ := attributes["sudo"].(map[string]interface{})
+		return sudo
+	}
+
+	model := newAssociationModel("user_group", groupId, "system_group", systemGroupId)
+	model.Sudo = newSudoConfig(true, true)
+
+	state, diags := createResource(t, r, model)
+	failOnDiagnostics(t, diags)
+
+	if sudo := sudoOnServer(); sudo["enabled"] != true || sudo["withoutPassword"] != true {
+		t.Errorf("Expected passwordless sudo on the server but got %v", sudo)
+	}
+
+	var created AssociationResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	if created.Id.ValueString() != "user_group/"+groupId+"/system_group/"+systemGroupId {
+		t.Errorf("Unexpected association id %s", created.Id.ValueString())
+	}
+
+	// Turning sudo off has to send both settings as false
+	model = created
+	model.Sudo = newSudoConfig(false, false)
+
+	state, diags = updateResource(t, r, state, model)
+	failOnDiagnostics(t, diags)
+
+	if sudo := sudoOnServer(); sudo["enabled"] != false || sudo["withoutPassword"] != false {
+		t.Errorf("Expected sudo to be turned off on the server but got %v", sudo)
+	}
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var read AssociationResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &read))
+
+	if read.Sudo == nil || read.Sudo.Enabled.ValueBool() || read.Sudo.Passwordless.ValueBool() {
+		t.Errorf("Expected sudo to be off after refresh but got %v", read.Sudo)
+	}
+
+	failOnDiagnostics(t, deleteResource(t, r, state))
+
+	if _, ok := server.Association(fakeserver.UserGroups, groupId, systemGroupId); ok {
+		t.Errorf("Expected the association to be removed")
+	}
+}
@@ -0,0 +1,42 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// encodeCustomAttribute converts the value of a custom attribute on JumpCloud to the
+// string kept in state, values that are not strings are JSON encoded
+func encodeCustomAttribute(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// decodeCustomAttribute converts a configured custom attribute back to the value sent to
+// JumpCloud. Attributes that currently hold a value that is not a string are decoded from
+// JSON so they keep their type, new attributes and string attributes are sent as is.
+func decodeCustomAttribute(value string, current map[string]interface{}, name string) (interface{}, error) {
+	existing, ok := current[name]
+	if !ok {
+		return value, nil
+	}
+
+	if _, isString := existing.(string); isString {
+		return value, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("%s is not a string on JumpCloud, its value has to be JSON encoded: %w", name, err)
+	}
+
+	return decoded, nil
+}
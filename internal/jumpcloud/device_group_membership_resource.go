@@ -0,0 +1,308 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/planmodifiers"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                = &DeviceGroupMembershipResource{}
+	_ resource.ResourceWithConfigure   = &DeviceGroupMembershipResource{}
+	_ resource.ResourceWithImportState = &DeviceGroupMembershipResource{}
+)
+
+func NewDeviceGroupMembershipResource() resource.Resource {
+	return &DeviceGroupMembershipResource{}
+}
+
+type DeviceGroupMembershipResource struct {
+	api *apiclient.Client
+}
+
+type DeviceGroupMembershipResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	GroupId       types.String `tfsdk:"group_id"`
+	Systems       types.Set    `tfsdk:"systems"`
+	Authoritative types.Bool   `tfsdk:"authoritative"`
+}
+
+func (r *DeviceGroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devicegroup_membership"
+}
+
+func (r *DeviceGroupMembershipResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Manages the devices (systems) that are members of a Device Group",
+		Version:             0,
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Resource ID, same as the group id (Computed / Read-Only)",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+			"group_id": {
+				MarkdownDescription: "ID of the Device Group",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"systems": {
+				MarkdownDescription: "Set of system ids that are members of the Device Group",
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Required: true,
+			},
+			"authoritative": {
+				MarkdownDescription: "When true (the default) systems that are not listed are removed from the group. " +
+					"When false only the listed systems are managed, so members added by other tooling are left alone",
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					planmodifiers.BoolDefaultModifier{
+						Default: true,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *DeviceGroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(JumpCloudApi)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.JumpCloudClientApi, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.api = &api.Internal
+}
+
+func (r *DeviceGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *DeviceGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.GroupId
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *DeviceGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Refreshing Device Group Membership State from JumpCloud")
+
+	var state *DeviceGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, error := r.api.ListSystemGroupMembers(ctx, state.GroupId.ValueString())
+	if apiclient.IsNotFound(error) {
+		tflog.Warn(ctx, "Device Group no longer exists on JumpCloud, removing its membership from state", map[string]interface{}{
+			"group": state.GroupId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retreiving Device Group Members from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	// Imported memberships have no mode yet and are treated as authoritative
+	if state.Authoritative.IsNull() || state.Authoritative.IsUnknown() {
+		state.Authoritative = types.BoolValue(true)
+	}
+
+	// In additive mode only the systems we manage are tracked, so that members
+	// added by other tooling never show up as drift
+	if !state.Authoritative.ValueBool() {
+		var managed []string
+		resp.Diagnostics.Append(state.Systems.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		current = append([]string{}, intersectMembers(current, managed)...)
+	}
+
+	systems, diags := types.SetValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = state.GroupId
+	state.Systems = systems
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *DeviceGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *DeviceGroupMembershipResourceModel
+	var state *DeviceGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.GroupId
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *DeviceGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *DeviceGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var systems []string
+	resp.Diagnostics.Append(state.Systems.ElementsAs(ctx, &systems, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the group already removed all of its members
+	current, error := r.api.ListSystemGroupMembers(ctx, state.GroupId.ValueString())
+	if apiclient.IsNotFound(error) {
+		return
+	}
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retreiving Device Group Members from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	// Only the members that are managed by this resource are removed
+	for _, systemId := range intersectMembers(current, systems) {
+		if _, error := r.api.RemoveSystemGroupMember(ctx, state.GroupId.ValueString(), systemId); error != nil && !apiclient.IsNotFound(error) {
+			resp.Diagnostics.AddError(
+				"Error removing System from Device Group",
+				fmt.Sprintf("Unable to remove system %s from group %s: %s", systemId, state.GroupId.ValueString(), error),
+			)
+		}
+	}
+}
+
+func (r *DeviceGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("group_id"), req, resp)
+}
+
+// reconcile brings the group in line with the plan. In authoritative mode the group
+// ends up with exactly the planned systems; in additive mode only the planned systems
+// are added and only systems dropped from the prior state are removed.
+func (r *DeviceGroupMembershipResource) reconcile(ctx context.Context, plan *DeviceGroupMembershipResourceModel, state *DeviceGroupMembershipResourceModel) (diags diag.Diagnostics) {
+	groupId := plan.GroupId.ValueString()
+
+	var desired []string
+	diags.Append(plan.Systems.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, error := r.api.ListSystemGroupMembers(ctx, groupId)
+	if error != nil {
+		diags.AddError(
+			"Error retreiving Device Group Members from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return diags
+	}
+
+	add, remove := diffMembers(current, desired)
+
+	if !plan.Authoritative.ValueBool() {
+		var previous []string
+		if state != nil {
+			diags.Append(state.Systems.ElementsAs(ctx, &previous, false)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		_, dropped := diffMembers(previous, desired)
+		remove = intersectMembers(remove, dropped)
+	}
+
+	tflog.Info(ctx, "Reconciling Device Group Membership", map[string]interface{}{
+		"group":         groupId,
+		"authoritative": plan.Authoritative.ValueBool(),
+		"add":           add,
+		"remove":        remove,
+	})
+
+	for _, systemId := range add {
+		if _, error := r.api.AddSystemGroupMember(ctx, groupId, systemId); error != nil {
+			diags.AddError(
+				"Error adding System to Device Group",
+				fmt.Sprintf("Unable to add system %s to group %s: %s", systemId, groupId, error),
+			)
+		}
+	}
+
+	for _, systemId := range remove {
+		if _, error := r.api.RemoveSystemGroupMember(ctx, groupId, systemId); error != nil {
+			diags.AddError(
+				"Error removing System from Device Group",
+				fmt.Sprintf("Unable to remove system %s from group %s: %s", systemId, groupId, error),
+			)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,62 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Systems can only be enrolled by the JumpCloud agent, so this test needs the id
+// of an existing system in the test organization
+func TestAccDeviceGroupMembershipResource(t *testing.T) {
+	testAccRecorder(t)
+
+	system_id := os.Getenv("JUMPCLOUD_TEST_SYSTEM_ID")
+	if system_id == "" {
+		t.Skip("JUMPCLOUD_TEST_SYSTEM_ID must be set to run device group membership tests")
+	}
+
+	test_env := GetTestEnv()
+	group_name := fmt.Sprintf("terraform-test-devicegroup-membership-%s", test_env)
+
+	config := func(authoritative bool) string {
+		return ProviderConfig() + `
+resource "jumpcloud_devicegroup" "test" {
+	name = "` + group_name + `"
+}
+
+resource "jumpcloud_devicegroup_membership" "test" {
+	group_id      = jumpcloud_devicegroup.test.id
+	systems       = ["` + system_id + `"]
+	authoritative = ` + fmt.Sprint(authoritative) + `
+}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("jumpcloud_devicegroup_membership.test", "id", "jumpcloud_devicegroup.test", "id"),
+					resource.TestCheckResourceAttr("jumpcloud_devicegroup_membership.test", "systems.#", "1"),
+					resource.TestCheckResourceAttr("jumpcloud_devicegroup_membership.test", "authoritative", "true"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_devicegroup_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_devicegroup_membership.test", "systems.#", "1"),
+					resource.TestCheckResourceAttr("jumpcloud_devicegroup_membership.test", "authoritative", "false"),
+				),
+			},
+		},
+	})
+}
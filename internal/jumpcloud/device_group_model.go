@@ -0,0 +1,27 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type DeviceGroupResourceModel struct {
+	Id                      types.String                 `tfsdk:"id"`
+	Name                    types.String                 `tfsdk:"name"`
+	Description             types.String                 `tfsdk:"description"`
+	Email                   types.String                 `tfsdk:"email"`
+	MemberQuery             *DeviceGroupMemberQueryModel `tfsdk:"member_query"`
+	MembershipMethod        types.String                 `tfsdk:"membership_method"`
+	MemberSuggestionsNotify types.Bool                   `tfsdk:"notify"`
+	Attributes              types.Map                    `tfsdk:"attributes"`
+	Timeouts                types.Object                 `tfsdk:"timeouts"`
+}
+
+type DeviceGroupMemberQueryModel struct {
+	Filters []QueryFilterModel `tfsdk:"filters"`
+}
+
+type QueryFilterModel struct {
+	Field    types.String `tfsdk:"field"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
@@ -4,34 +4,31 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/api"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	"github.com/davecgh/go-spew/spew"
-
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
-var _ resource.Resource = &DeviceGroupResource{}
-var _ resource.ResourceWithImportState = &DeviceGroupResource{}
+var (
+	_ resource.Resource                   = &DeviceGroupResource{}
+	_ resource.ResourceWithConfigure      = &DeviceGroupResource{}
+	_ resource.ResourceWithValidateConfig = &DeviceGroupResource{}
+	_ resource.ResourceWithImportState    = &DeviceGroupResource{}
+)
 
 func NewDeviceGroupResource() resource.Resource {
 	return &DeviceGroupResource{}
 }
 
 type DeviceGroupResource struct {
-	api *api.JumpCloudClientApiV2
-}
-
-type DeviceGroupResourceModel struct {
-	Id   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	api *apiclient.Client
 }
 
 func (r *DeviceGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -39,26 +36,28 @@ func (r *DeviceGroupResource) Metadata(ctx context.Context, req resource.Metadat
 }
 
 func (r *DeviceGroupResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		MarkdownDescription: "Device Group",
-		Version:             0,
-
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Computed:            true,
-				MarkdownDescription: "Resource ID (Computed / Read-Only)",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					resource.UseStateForUnknown(),
-				},
-				Type: types.StringType,
-			},
-			"name": {
-				MarkdownDescription: "Name for the Device Group",
-				Type:                types.StringType,
-				Required:            true,
-			},
-		},
-	}, nil
+	return DeviceGroupSchema, nil
+}
+
+func (r *DeviceGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DeviceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.MembershipMethod.IsUnknown() || config.MembershipMethod.IsNull() {
+		return
+	}
+
+	if config.MembershipMethod.ValueString() != apiclient.MEMBERSHIP_METHOD_STATIC && config.MemberQuery == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_query"),
+			"Missing Member Query",
+			fmt.Sprintf("A member_query is required when membership_method is %s", config.MembershipMethod.ValueString()),
+		)
+	}
 }
 
 func (r *DeviceGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -77,7 +76,7 @@ func (r *DeviceGroupResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	r.api = &api.V2
+	r.api = &api.Internal
 }
 
 func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -89,132 +88,258 @@ func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	var name = plan.Name.ValueString()
+	ctx, cancel := timeouts.WithTimeout(ctx, plan.Timeouts, timeouts.CREATE)
+	defer cancel()
 
-	var options = make(map[string]interface{})
-	options["body"] = jcapiv2.SystemGroupData{
-		Name: name,
+	group, diags := convertResourceToSystemGroup(ctx, plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Calling GroupsSystemPost with\n%s", spew.Sdump(options)))
-
-	group, response, error := r.api.Client.SystemGroupsApi.GroupsSystemPost(r.api.Auth, api.API_ACCEPT_TYPE, api.API_CONTENT_TYPE, options)
+	created, _, error := r.api.CreateSystemGroup(ctx, &group)
 
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Device Group",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 		return
 	}
 
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
-	tflog.Info(ctx, fmt.Sprintf("Created new Device Group\n%s", spew.Sdump(group)))
-
-	plan.Id = types.StringValue(group.Id)
-	plan.Name = types.StringValue(group.Name)
+	tflog.Info(ctx, "Created new Device Group", map[string]interface{}{
+		"id":   created.Id,
+		"name": created.Name,
+	})
 
-	var diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(convertSystemGroupToResource(ctx, plan, &created)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *DeviceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state DeviceGroupResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	var state *DeviceGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := timeouts.WithTimeout(ctx, state.Timeouts, timeouts.READ)
+	defer cancel()
+
 	tflog.Info(ctx, "Refreshing Device Group State from JumpCloud")
 
-	group, response, error := r.api.Client.SystemGroupsApi.GroupsSystemGet(r.api.Auth, state.Id.ValueString(), api.API_CONTENT_TYPE, api.API_ACCEPT_TYPE, nil)
+	group, _, error := r.api.GetSystemGroupDetails(ctx, state.Id.ValueString())
+
+	if apiclient.IsNotFound(error) {
+		tflog.Warn(ctx, "Device Group no longer exists on JumpCloud, removing it from state", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
 	if error != nil {
 		resp.Diagnostics.AddError(
-			"Error retreiving Active Directory from JumpCloud",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			"Error retreiving Device Group from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
 
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
-
-	state.Name = types.StringValue(group.Name)
-
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(convertSystemGroupToResource(ctx, state, &group)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
 func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan *DeviceGroupResourceModel
 
-	var state DeviceGroupResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var name = plan.Name.ValueString()
+	ctx, cancel := timeouts.WithTimeout(ctx, plan.Timeouts, timeouts.UPDATE)
+	defer cancel()
 
-	var options = make(map[string]interface{})
-	options["body"] = jcapiv2.SystemGroupData{
-		Name: name,
-	}
+	// The current attributes decide which configured values are sent decoded from JSON
+	current, _, error := r.api.GetSystemGroupDetails(ctx, plan.Id.ValueString())
 
-	tflog.Info(ctx, fmt.Sprintf("Calling GroupsSystemPut with\n%s", spew.Sdump(options)))
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Device Group from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+
+		return
+	}
 
-	group, response, error := r.api.Client.SystemGroupsApi.GroupsSystemPut(r.api.Auth, state.Id.ValueString(), api.API_CONTENT_TYPE, api.API_ACCEPT_TYPE, options)
+	group, diags := convertResourceToSystemGroup(ctx, plan, current.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
+	updated, _, error := r.api.UpdateSystemGroup(ctx, &group)
 
 	if error != nil {
 		resp.Diagnostics.AddError(
-			"Error deleting Device from JumpCloud",
-			fmt.Sprintf("AP Error: %s", spew.Sdump(error)),
+			"Error updating Device Group on JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
 
-	state.Name = types.StringValue(group.Name)
-
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(convertSystemGroupToResource(ctx, plan, &updated)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state DeviceGroupResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	var state *DeviceGroupResourceModel
 
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	response, error := r.api.Client.SystemGroupsApi.GroupsSystemDelete(r.api.Auth, state.Id.ValueString(), api.API_CONTENT_TYPE, api.API_ACCEPT_TYPE, nil)
-	if error != nil {
+	ctx, cancel := timeouts.WithTimeout(ctx, state.Timeouts, timeouts.DELETE)
+	defer cancel()
+
+	// A device group that is already gone counts as deleted
+	_, error := r.api.DeleteSystemGroup(ctx, state.Id.ValueString())
+	if error != nil && !apiclient.IsNotFound(error) {
 		resp.Diagnostics.AddError(
-			"Error deleting Device from JumpCloud",
-			fmt.Sprintf("AP Error: %s", spew.Sdump(error)),
+			"Error deleting Device Group from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
 		)
+	}
+}
+
+// ImportState accepts either the id or the exact name of an existing device group
+func (r *DeviceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if objectIdPattern.MatchString(req.ID) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
 
+	group, _, error := r.api.GetSystemGroupByName(ctx, req.ID)
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error importing Device Group from JumpCloud",
+			fmt.Sprintf("Unable to find device group named %q: %s", req.ID, error),
+		)
 		return
 	}
 
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response))
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), group.Id)...)
 }
 
-func (r *DeviceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+func convertSystemGroupToResource(ctx context.Context, resourceModel *DeviceGroupResourceModel, apiModel *apiclient.SystemGroup) (diags diag.Diagnostics) {
+	resourceModel.Id = types.StringValue(apiModel.Id)
+	resourceModel.Name = types.StringValue(apiModel.Name)
+	resourceModel.Description = types.StringValue(apiModel.Description)
+	resourceModel.Email = types.StringValue(apiModel.Email)
+	resourceModel.MemberSuggestionsNotify = types.BoolValue(apiModel.MemberSuggestionsNotify)
+
+	// Groups created before dynamic membership existed have no membership method
+	resourceModel.MembershipMethod = types.StringValue(apiModel.MembershipMethod)
+	if apiModel.MembershipMethod == "" {
+		resourceModel.MembershipMethod = types.StringValue(apiclient.MEMBERSHIP_METHOD_STATIC)
+	}
+
+	resourceModel.MemberQuery = nil
+	if apiModel.MemberQuery != nil && len(apiModel.MemberQuery.Filters) > 0 {
+		resourceModel.MemberQuery = &DeviceGroupMemberQueryModel{}
+		for _, filter := range apiModel.MemberQuery.Filters {
+			resourceModel.MemberQuery.Filters = append(resourceModel.MemberQuery.Filters, QueryFilterModel{
+				Field:    types.StringValue(filter.Field),
+				Operator: types.StringValue(filter.Operator),
+				Value:    types.StringValue(filter.Value),
+			})
+		}
+	}
+
+	if len(apiModel.Attributes) > 0 || !resourceModel.Attributes.IsNull() {
+		attributes := make(map[string]string, len(apiModel.Attributes))
+		for name, value := range apiModel.Attributes {
+			encoded, err := encodeCustomAttribute(value)
+			if err != nil {
+				diags.AddError("Unable to convert Device Group attribute", fmt.Sprintf("Attribute %s: %s", name, err))
+				return diags
+			}
+			attributes[name] = encoded
+		}
+
+		value, d := types.MapValueFrom(ctx, types.StringType, attributes)
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+
+		resourceModel.Attributes = value
+	}
+
+	return diags
+}
+
+// convertResourceToSystemGroup converts the model to the group sent to JumpCloud, current
+// holds the attributes the group has on JumpCloud and is nil for a new group
+func convertResourceToSystemGroup(ctx context.Context, resourceModel *DeviceGroupResourceModel, current map[string]interface{}) (apiModel apiclient.SystemGroup, diags diag.Diagnostics) {
+	apiModel = apiclient.SystemGroup{
+		Id:                      resourceModel.Id.ValueString(),
+		Name:                    resourceModel.Name.ValueString(),
+		Description:             resourceModel.Description.ValueString(),
+		Email:                   resourceModel.Email.ValueString(),
+		MembershipMethod:        resourceModel.MembershipMethod.ValueString(),
+		MemberSuggestionsNotify: resourceModel.MemberSuggestionsNotify.ValueBool(),
+	}
+
+	if resourceModel.MemberQuery != nil {
+		apiModel.MemberQuery = &apiclient.SystemGroupMemberQuery{
+			QueryType: apiclient.MEMBER_QUERY_TYPE_FILTER,
+		}
+
+		for _, filter := range resourceModel.MemberQuery.Filters {
+			apiModel.MemberQuery.Filters = append(apiModel.MemberQuery.Filters, apiclient.QueryFilter{
+				Field:    filter.Field.ValueString(),
+				Operator: filter.Operator.ValueString(),
+				Value:    filter.Value.ValueString(),
+			})
+		}
+	}
+
+	if !resourceModel.Attributes.IsNull() && !resourceModel.Attributes.IsUnknown() {
+		var attributes map[string]string
+		diags.Append(resourceModel.Attributes.ElementsAs(ctx, &attributes, false)...)
+		if diags.HasError() {
+			return apiModel, diags
+		}
+
+		apiModel.Attributes = make(map[string]interface{}, len(attributes))
+		for name, value := range attributes {
+			decoded, err := decodeCustomAttribute(value, current, name)
+			if err != nil {
+				diags.AddAttributeError(path.Root("attributes").AtMapKey(name), "Invalid Device Group attribute", err.Error())
+				continue
+			}
+			apiModel.Attributes[name] = decoded
+		}
+	}
+
+	return apiModel, diags
 }
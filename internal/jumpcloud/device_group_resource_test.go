@@ -0,0 +1,317 @@
+package jumpcloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
+)
+
+func newDeviceGroupModel(name string) DeviceGroupResourceModel {
+	return DeviceGroupResourceModel{
+		Id:         types.StringUnknown(),
+		Name:       types.StringValue(name),
+		Attributes: types.MapNull(types.StringType),
+		Timeouts:   timeouts.Null(),
+	}
+}
+
+func TestDeviceGroupResourceCreateReadDelete(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewDeviceGroupResource)
+
+	state, diags := createResource(t, r, newDeviceGroupModel("servers"))
+	failOnDiagnostics(t, diags)
+
+	var created DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	if group, ok := server.Get(fakeserver.SystemGroups, created.Id.ValueString()); !ok || group["name"] != "servers" {
+		t.Fatalf("Expected device group %s named servers on the server but got %v", created.Id.ValueString(), group)
+	}
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var read DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &read))
+
+	if read.Id != created.Id || read.Name != created.Name || read.MembershipMethod.ValueString() != apiclient.MEMBERSHIP_METHOD_STATIC {
+		t.Errorf("Expected %v after refresh but got %v", created, read)
+	}
+
+	failOnDiagnostics(t, deleteResource(t, r, state))
+
+	if _, ok := server.Get(fakeserver.SystemGroups, created.Id.ValueString()); ok {
+		t.Errorf("Expected device group %s to be deleted", created.Id.ValueString())
+	}
+
+	// Deleting it again, eg after it was removed in the console, is not an error
+	failOnDiagnostics(t, deleteResource(t, r, state))
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	if !state.Raw.IsNull() {
+		t.Errorf("Expected the device group to be removed from state")
+	}
+}
+
+func TestDeviceGroupResourceUnconfiguredDefaults(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	schema := resourceSchema(t, newFakeResource(t, server, NewDeviceGroupResource))
+
+	// Values set in the console are reset to the default when they are not configured
+	tests := map[string]string{
+		"description":       "",
+		"email":             "",
+		"membership_method": apiclient.MEMBERSHIP_METHOD_STATIC,
+	}
+
+	for name, expected := range tests {
+		if planned := planUnconfigured(t, schema, name, types.StringValue("Set in the console")); planned.ValueString() != expected || planned.IsUnknown() {
+			t.Errorf("Expected an unconfigured %s to be planned as %q but got %v", name, expected, planned)
+		}
+	}
+}
+
+func TestDeviceGroupResourceCreateTimeout(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	r := newFakeResource(t, server, NewDeviceGroupResource)
+
+	model := newDeviceGroupModel("servers")
+	model.Timeouts = types.ObjectValueMust(timeouts.AttrTypes(), map[string]attr.Value{
+		timeouts.CREATE: types.StringValue("1ns"),
+		timeouts.READ:   types.StringNull(),
+		timeouts.UPDATE: types.StringNull(),
+		timeouts.DELETE: types.StringNull(),
+	})
+
+	_, diags := createResource(t, r, model)
+
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), context.DeadlineExceeded.Error()) {
+		t.Errorf("Expected the create to fail once its timeout passed but got %v", diags)
+	}
+}
+
+func TestDeviceGroupResourceDynamicMembership(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewDeviceGroupResource)
+
+	model := newDeviceGroupModel("macs")
+	model.Description = types.StringValue("Every macOS device")
+	model.MembershipMethod = types.StringValue(apiclient.MEMBERSHIP_METHOD_DYNAMIC_AUTOMATED)
+	model.MemberSuggestionsNotify = types.BoolValue(true)
+	model.MemberQuery = &DeviceGroupMemberQueryModel{
+		Filters: []QueryFilterModel{
+			{Field: types.StringValue("os"), Operator: types.StringValue("eq"), Value: types.StringValue("Mac OS X")},
+			{Field: types.StringValue("fde.active"), Operator: types.StringValue("eq"), Value: types.StringValue("true")},
+		},
+	}
+	model.Attributes = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"team": types.StringValue("it"),
+	})
+
+	state, diags := createResource(t, r, model)
+	failOnDiagnostics(t, diags)
+
+	var created DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	group, _ := server.Get(fakeserver.SystemGroups, created.Id.ValueString())
+	query, _ := group["memberQuery"].(map[string]interface{})
+	filters, _ := query["filters"].([]interface{})
+
+	if group["membershipMethod"] != apiclient.MEMBERSHIP_METHOD_DYNAMIC_AUTOMATED || query["queryType"] != apiclient.MEMBER_QUERY_TYPE_FILTER || len(filters) != 2 {
+		t.Fatalf("Expected a dynamic group with two filters on the server but got %v", group)
+	}
+
+	// Changes made in the console show up as drift
+	server.Edit(fakeserver.SystemGroups, created.Id.ValueString(), fakeserver.Object{
+		"attributes": map[string]interface{}{"team": "security", "priority": 1},
+	})
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var read DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &read))
+
+	var attributes map[string]string
+	failOnDiagnostics(t, read.Attributes.ElementsAs(ctx, &attributes, false))
+
+	if attributes["team"] != "security" || attributes["priority"] != "1" {
+		t.Errorf("Expected the attributes edited on JumpCloud but got %v", attributes)
+	}
+
+	if read.MemberQuery == nil || len(read.MemberQuery.Filters) != 2 || read.MemberQuery.Filters[1].Field.ValueString() != "fde.active" {
+		t.Errorf("Expected the member query to survive a refresh but got %v", read.MemberQuery)
+	}
+
+	if read.Description.ValueString() != "Every macOS device" || !read.MemberSuggestionsNotify.ValueBool() {
+		t.Errorf("Unexpected Device Group after refresh %v", read)
+	}
+
+	// Going back to a static group drops the query
+	model.Id = created.Id
+	model.MembershipMethod = types.StringValue(apiclient.MEMBERSHIP_METHOD_STATIC)
+	model.MemberQuery = nil
+
+	state, diags = updateResource(t, r, state, model)
+	failOnDiagnostics(t, diags)
+
+	failOnDiagnostics(t, state.Get(ctx, &read))
+	if read.MemberQuery != nil || read.MembershipMethod.ValueString() != apiclient.MEMBERSHIP_METHOD_STATIC {
+		t.Errorf("Expected a static Device Group without member query but got %v", read)
+	}
+
+	if group, _ := server.Get(fakeserver.SystemGroups, created.Id.ValueString()); group["memberQuery"] != nil {
+		t.Errorf("Expected the member query to be removed on the server but got %v", group["memberQuery"])
+	}
+}
+
+func TestDeviceGroupResourceAttributesKeepTheirType(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewDeviceGroupResource)
+
+	id := server.Seed(fakeserver.SystemGroups, fakeserver.Object{
+		"name":       "servers",
+		"attributes": map[string]interface{}{"priority": 1, "tags": []interface{}{"prod"}, "team": "it"},
+	})["id"].(string)
+
+	state, diags := importResource(t, r, id)
+	failOnDiagnostics(t, diags)
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var imported DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &imported))
+
+	var attributes map[string]string
+	failOnDiagnostics(t, imported.Attributes.ElementsAs(ctx, &attributes, false))
+
+	if attributes["priority"] != "1" || attributes["tags"] != `["prod"]` || attributes["team"] != "it" {
+		t.Fatalf("Expected the attributes JSON encoded but got %v", attributes)
+	}
+
+	// Changing the priority and adding an attribute that only looks like a number
+	model := imported
+	model.Attributes = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"priority": types.StringValue("2"),
+		"tags":     types.StringValue(`["prod"]`),
+		"team":     types.StringValue("it"),
+		"rack":     types.StringValue("42"),
+	})
+
+	_, diags = updateResource(t, r, state, model)
+	failOnDiagnostics(t, diags)
+
+	group, _ := server.Get(fakeserver.SystemGroups, id)
+	sent, _ := group["attributes"].(map[string]interface{})
+	tags, _ := sent["tags"].([]interface{})
+
+	if sent["priority"] != float64(2) || len(tags) != 1 || tags[0] != "prod" || sent["team"] != "it" || sent["rack"] != "42" {
+		t.Errorf("Expected the attributes to keep their type on the server but got %v", sent)
+	}
+
+	// A value that is not JSON cannot replace a number
+	model.Attributes = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"priority": types.StringValue("high"),
+	})
+
+	if _, diags = updateResource(t, r, state, model); !diags.HasError() {
+		t.Errorf("Expected a non JSON value for a number attribute to be rejected")
+	}
+}
+
+func TestDeviceGroupResourceRename(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewDeviceGroupResource)
+
+	state, diags := createResource(t, r, newDeviceGroupModel("servers"))
+	failOnDiagnostics(t, diags)
+
+	var created DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	model := newDeviceGroupModel("linux-servers")
+	model.Id = created.Id
+
+	state, diags = updateResource(t, r, state, model)
+	failOnDiagnostics(t, diags)
+
+	var updated DeviceGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &updated))
+
+	if updated.Id != created.Id || updated.Name.ValueString() != "linux-servers" {
+		t.Errorf("Expected Device Group %s to be renamed in place but got %v", created.Id.ValueString(), updated)
+	}
+
+	if group, _ := server.Get(fakeserver.SystemGroups, created.Id.ValueString()); group["name"] != "linux-servers" {
+		t.Errorf("Expected the Device Group to be renamed on the server but got %v", group["name"])
+	}
+}
+
+func TestDeviceGroupResourceImport(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewDeviceGroupResource)
+
+	id := server.Seed(fakeserver.SystemGroups, fakeserver.Object{
+		"name":             "servers",
+		"description":      "Created in the console",
+		"membershipMethod": apiclient.MEMBERSHIP_METHOD_STATIC,
+	})["id"].(string)
+	server.Seed(fakeserver.SystemGroups, fakeserver.Object{"name": "duplicate"})
+	server.Seed(fakeserver.SystemGroups, fakeserver.Object{"name": "duplicate"})
+
+	for _, importId := range []string{id, "servers"} {
+		t.Run(importId, func(t *testing.T) {
+			state, diags := importResource(t, r, importId)
+			failOnDiagnostics(t, diags)
+
+			state, diags = readResource(t, r, state)
+			failOnDiagnostics(t, diags)
+
+			var imported DeviceGroupResourceModel
+			failOnDiagnostics(t, state.Get(ctx, &imported))
+
+			if imported.Id.ValueString() != id || imported.Name.ValueString() != "servers" || imported.Description.ValueString() != "Created in the console" {
+				t.Errorf("Unexpected imported Device Group %v", imported)
+			}
+		})
+	}
+
+	for _, importId := range []string{"missing", "duplicate"} {
+		t.Run(importId, func(t *testing.T) {
+			if _, diags := importResource(t, r, importId); !diags.HasError() {
+				t.Errorf("Expected importing %q to fail", importId)
+			}
+		})
+	}
+}
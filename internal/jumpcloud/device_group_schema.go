@@ -0,0 +1,122 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/planmodifiers"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
+)
+
+var DeviceGroupSchema = tfsdk.Schema{
+	MarkdownDescription: "Device Group",
+	Version:             0,
+
+	Attributes: map[string]tfsdk.Attribute{
+		"id": {
+			Computed:            true,
+			MarkdownDescription: "Resource ID (Computed / Read-Only)",
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Type: types.StringType,
+		},
+		"name": {
+			MarkdownDescription: "Name for the Device Group",
+			Type:                types.StringType,
+			Required:            true,
+		},
+		"description": {
+			MarkdownDescription: "Description for the Device Group",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				planmodifiers.StringConfigDefaultModifier{
+					Default: "",
+				},
+			},
+		},
+		"email": {
+			MarkdownDescription: "E-Mail Address for the Device Group",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				planmodifiers.StringConfigDefaultModifier{
+					Default: "",
+				},
+			},
+		},
+		"member_query": {
+			MarkdownDescription: "Devices matching every filter become members of a dynamic Device Group, see `membership_method`",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"filters": {
+					MarkdownDescription: "Filters a device has to match",
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+						"field": {
+							MarkdownDescription: "The name of the device field to query, eg `os` or `fde.active`",
+							Type:                types.StringType,
+							Required:            true,
+						},
+						"operator": {
+							MarkdownDescription: "The operator to use for the query",
+							Type:                types.StringType,
+							Required:            true,
+							Validators: []tfsdk.AttributeValidator{
+								stringvalidator.OneOf([]string{"eq", "ne", "gt", "lt", "ge", "le", "between", "search", "in"}...),
+							},
+						},
+						"value": {
+							MarkdownDescription: "The value for the filter expression",
+							Type:                types.StringType,
+							Required:            true,
+						},
+					}),
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"membership_method": {
+			MarkdownDescription: "How devices become members: `STATIC` (through `jumpcloud_devicegroup_membership`), `DYNAMIC_REVIEW_REQUIRED` (devices matching `member_query` are suggested to an administrator) or `DYNAMIC_AUTOMATED` (devices matching `member_query` are added automatically). Defaults to `STATIC`.",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				planmodifiers.StringConfigDefaultModifier{
+					Default: apiclient.MEMBERSHIP_METHOD_STATIC,
+				},
+			},
+			Validators: []tfsdk.AttributeValidator{
+				stringvalidator.OneOf(
+					apiclient.MEMBERSHIP_METHOD_STATIC,
+					apiclient.MEMBERSHIP_METHOD_DYNAMIC_REVIEW_REQUIRED,
+					apiclient.MEMBERSHIP_METHOD_DYNAMIC_AUTOMATED,
+				),
+			},
+		},
+		"notify": {
+			MarkdownDescription: "Whether to send notifications for new member suggestions that match `member_query`",
+			Type:                types.BoolType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				planmodifiers.BoolDefaultModifier{
+					Default: false,
+				},
+			},
+		},
+		"attributes": {
+			MarkdownDescription: "Map of custom attributes to set on the Device Group. Attributes that are not strings on JumpCloud are shown JSON encoded and their values are sent decoded from JSON, so they keep their type.",
+			Type: types.MapType{
+				ElemType: types.StringType,
+			},
+			Optional: true,
+		},
+		"timeouts": timeouts.Attribute(),
+	},
+}
@@ -0,0 +1,271 @@
+package jumpcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+)
+
+// The helpers below drive resources directly through the framework interfaces
+// against a fakeserver.Server, so they run offline and without a Terraform binary.
+
+// newFakeProviderData configures the provider against the fake server and returns
+// the data it hands to resources
+func newFakeProviderData(t *testing.T, server *fakeserver.Server) JumpCloudApi {
+	t.Helper()
+
+	// Keep settings meant for the acceptance tests away from the fake server
+	t.Setenv("JUMPCLOUD_ORG_ID", "")
+	t.Setenv("JUMPCLOUD_RECORDER_MODE", "")
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schema, diags := p.GetSchema(ctx)
+	failOnDiagnostics(t, diags)
+
+	raw := nullAttributes(ctx, schema)
+	raw["api_key"] = tftypes.NewValue(tftypes.String, fakeserver.ApiKey)
+	raw["api_url"] = tftypes.NewValue(tftypes.String, server.BaseURL())
+	raw["max_retries"] = tftypes.NewValue(tftypes.Number, 0)
+
+	resp := provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schema,
+			Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), raw),
+		},
+	}, &resp)
+	failOnDiagnostics(t, resp.Diagnostics)
+
+	return resp.ResourceData.(JumpCloudApi)
+}
+
+// newFakeResource returns a resource configured against the fake server
+func newFakeResource(t *testing.T, server *fakeserver.Server, newResource func() resource.Resource) resource.Resource {
+	t.Helper()
+
+	r := newResource()
+
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		resp := resource.ConfigureResponse{}
+		configurable.Configure(context.Background(), resource.ConfigureRequest{ProviderData: newFakeProviderData(t, server)}, &resp)
+		failOnDiagnostics(t, resp.Diagnostics)
+	}
+
+	return r
+}
+
+// newFakeDataSource returns a data source configured against the fake server
+func newFakeDataSource(t *testing.T, server *fakeserver.Server, newDataSource func() datasource.DataSource) datasource.DataSource {
+	t.Helper()
+
+	d := newDataSource()
+
+	if configurable, ok := d.(datasource.DataSourceWithConfigure); ok {
+		resp := datasource.ConfigureResponse{}
+		configurable.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: newFakeProviderData(t, server)}, &resp)
+		failOnDiagnostics(t, resp.Diagnostics)
+	}
+
+	return d
+}
+
+// readDataSource reads a data source with the configuration built from model
+func readDataSource(t *testing.T, d datasource.DataSource, model interface{}) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schema, diags := d.GetSchema(ctx)
+	failOnDiagnostics(t, diags)
+
+	// Config has no setter, the raw value is built through a state with the same schema
+	state := tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+	failOnDiagnostics(t, state.Set(ctx, model))
+
+	resp := datasource.ReadResponse{State: state}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schema, Raw: state.Raw}}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+func resourceSchema(t *testing.T, r resource.Resource) tfsdk.Schema {
+	t.Helper()
+
+	schema, diags := r.GetSchema(context.Background())
+	failOnDiagnostics(t, diags)
+
+	return schema
+}
+
+// newPlan builds a plan from a resource model, leaving computed values unknown is up to the caller
+func newPlan(t *testing.T, r resource.Resource, model interface{}) tfsdk.Plan {
+	t.Helper()
+
+	ctx := context.Background()
+	schema := resourceSchema(t, r)
+
+	plan := tfsdk.Plan{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+	failOnDiagnostics(t, plan.Set(ctx, model))
+
+	return plan
+}
+
+func emptyState(t *testing.T, r resource.Resource) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	schema := resourceSchema(t, r)
+
+	return tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+func createResource(t *testing.T, r resource.Resource, model interface{}) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	plan := newPlan(t, r, model)
+	resp := resource.CreateResponse{State: emptyState(t, r)}
+
+	r.Create(context.Background(), resource.CreateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+	}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+func readResource(t *testing.T, r resource.Resource, state tfsdk.State) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+func updateResource(t *testing.T, r resource.Resource, state tfsdk.State, model interface{}) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	plan := newPlan(t, r, model)
+	resp := resource.UpdateResponse{State: state}
+
+	r.Update(context.Background(), resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+		State:  state,
+	}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+func deleteResource(t *testing.T, r resource.Resource, state tfsdk.State) diag.Diagnostics {
+	t.Helper()
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+
+	return resp.Diagnostics
+}
+
+func validateConfig(t *testing.T, r resource.Resource, model interface{}) diag.Diagnostics {
+	t.Helper()
+
+	plan := newPlan(t, r, model)
+	resp := resource.ValidateConfigResponse{}
+
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, &resp)
+
+	return resp.Diagnostics
+}
+
+// importResource imports id into an empty state the way Terraform does before
+// refreshing the imported resource
+func importResource(t *testing.T, r resource.Resource, id string) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	resp := resource.ImportStateResponse{State: emptyState(t, r)}
+	r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+
+	return resp.State, resp.Diagnostics
+}
+
+// upgradeResourceState hands rawState, saved by version of the resource schema, to the
+// provider the way Terraform does and returns the upgraded state
+func upgradeResourceState(t *testing.T, r resource.Resource, typeName string, version int64, rawState string) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	schema := resourceSchema(t, r)
+
+	// Terraform always fetches the schemas first, which also registers the resource types
+	server := providerserver.NewProtocol6(New("test")())()
+	if _, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{}); err != nil {
+		t.Fatalf("Unable to get the provider schema: %s", err)
+	}
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+	})
+	if err != nil {
+		t.Fatalf("Unable to upgrade the state: %s", err)
+	}
+
+	var diags diag.Diagnostics
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			diags.AddError(d.Summary, d.Detail)
+		}
+	}
+
+	state := emptyState(t, r)
+	if resp.UpgradedState != nil {
+		raw, err := resp.UpgradedState.Unmarshal(schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatalf("Unable to read the upgraded state: %s", err)
+		}
+		state.Raw = raw
+	}
+
+	return state, diags
+}
+
+func nullAttributes(ctx context.Context, schema tfsdk.Schema) map[string]tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attribute := range schema.Attributes {
+		values[name] = tftypes.NewValue(attribute.FrameworkType().TerraformType(ctx), nil)
+	}
+
+	return values
+}
+
+func failOnDiagnostics(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,55 @@
+package jumpcloud
+
+import "sort"
+
+// diffMembers computes which ids have to be added to and removed from current
+// so that it matches desired. Results are sorted to keep API calls deterministic.
+func diffMembers(current []string, desired []string) (add []string, remove []string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+
+	desiredSet := make(map[string]bool, len(desired))
+	for _, id := range desired {
+		if desiredSet[id] {
+			continue
+		}
+
+		desiredSet[id] = true
+
+		if !currentSet[id] {
+			add = append(add, id)
+		}
+	}
+
+	for id := range currentSet {
+		if !desiredSet[id] {
+			remove = append(remove, id)
+		}
+	}
+
+	sort.Strings(add)
+	sort.Strings(remove)
+
+	return add, remove
+}
+
+// intersectMembers returns the sorted ids that are present in both a and b
+func intersectMembers(a []string, b []string) (both []string) {
+	bSet := make(map[string]bool, len(b))
+	for _, id := range b {
+		bSet[id] = true
+	}
+
+	for _, id := range a {
+		if bSet[id] {
+			both = append(both, id)
+			delete(bSet, id)
+		}
+	}
+
+	sort.Strings(both)
+
+	return both
+}
@@ -0,0 +1,64 @@
+package jumpcloud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMembers(t *testing.T) {
+	tests := map[string]struct {
+		current []string
+		desired []string
+		add     []string
+		remove  []string
+	}{
+		"empty": {},
+		"add all": {
+			desired: []string{"b", "a"},
+			add:     []string{"a", "b"},
+		},
+		"remove all": {
+			current: []string{"a", "b"},
+			remove:  []string{"a", "b"},
+		},
+		"unchanged": {
+			current: []string{"a", "b"},
+			desired: []string{"b", "a"},
+		},
+		"mixed": {
+			current: []string{"a", "b", "c"},
+			desired: []string{"c", "d", "a"},
+			add:     []string{"d"},
+			remove:  []string{"b"},
+		},
+		"duplicates": {
+			current: []string{"a", "a"},
+			desired: []string{"b", "b"},
+			add:     []string{"b"},
+			remove:  []string{"a"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			add, remove := diffMembers(test.current, test.desired)
+
+			if !reflect.DeepEqual(add, test.add) {
+				t.Errorf("Expected add %v but got %v", test.add, add)
+			}
+
+			if !reflect.DeepEqual(remove, test.remove) {
+				t.Errorf("Expected remove %v but got %v", test.remove, remove)
+			}
+		})
+	}
+}
+
+func TestIntersectMembers(t *testing.T) {
+	both := intersectMembers([]string{"c", "a", "b", "a"}, []string{"a", "c", "d"})
+	expect := []string{"a", "c"}
+
+	if !reflect.DeepEqual(both, expect) {
+		t.Fatalf("Expected %v but got %v", expect, both)
+	}
+}
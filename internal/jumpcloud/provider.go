@@ -2,8 +2,13 @@ package jumpcloud
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -27,6 +32,13 @@ type JumpCloudProvider struct {
 
 type JumpCloudProviderModel struct {
 	APIKey types.String `tfsdk:"api_key"`
+	APIUrl types.String `tfsdk:"api_url"`
+	OrgId  types.String `tfsdk:"org_id"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryMaxWait types.Int64 `tfsdk:"retry_max_wait"`
+
+	RequestsPerSecond types.Int64 `tfsdk:"requests_per_second"`
 }
 
 type JumpCloudApi struct {
@@ -49,6 +61,42 @@ func (p *JumpCloudProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 				Type:                types.StringType,
 				Sensitive:           true,
 			},
+			"api_url": {
+				MarkdownDescription: "Root URL of the JumpCloud API, eg `https://console.eu.jumpcloud.com/api` for EU organizations. " +
+					"May also be set with the `JUMPCLOUD_API_URL` environment variable. Defaults to `" + apiclient.JUMPCLOUD_API_BASE_URL + "`",
+				Optional: true,
+				Type:     types.StringType,
+			},
+			"org_id": {
+				MarkdownDescription: "ID of the organization to manage when using a multi-tenant (MSP) administrator API key. " +
+					"May also be set with the `JUMPCLOUD_ORG_ID` environment variable. Use one provider alias per organization to manage several at once",
+				Optional: true,
+				Type:     types.StringType,
+			},
+			"max_retries": {
+				MarkdownDescription: fmt.Sprintf("How many times a request that was rate limited (429) or hit a transient server error (502, 503, 504 or a connection reset) is retried. Defaults to `%d`", apiclient.DEFAULT_MAX_RETRIES),
+				Optional:            true,
+				Type:                types.Int64Type,
+				Validators: []tfsdk.AttributeValidator{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": {
+				MarkdownDescription: fmt.Sprintf("The most requests per second sent to the JumpCloud API, shared by all resources and data sources of this provider. Set to `0` to disable client-side rate limiting. Defaults to `%d`", apiclient.DEFAULT_REQUESTS_PER_SECOND),
+				Optional:            true,
+				Type:                types.Int64Type,
+				Validators: []tfsdk.AttributeValidator{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": {
+				MarkdownDescription: fmt.Sprintf("The longest time in seconds to wait between two attempts, including waits requested by `Retry-After`. Defaults to `%d`", int64(apiclient.DEFAULT_RETRY_MAX_WAIT/time.Second)),
+				Optional:            true,
+				Type:                types.Int64Type,
+				Validators: []tfsdk.AttributeValidator{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}, nil
 }
@@ -70,6 +118,27 @@ func (p *JumpCloudProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	// An unknown URL would silently fall back to the US endpoint
+	if config.APIUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Unknown JumpCloud API URL",
+			"The provider cannot create the JumpCloud API client as the API URL is not known until apply. "+
+				"Set api_url to a value known during plan or use the JUMPCLOUD_API_URL environment variable.",
+		)
+	}
+
+	// An unknown organization would send requests without x-org-id, so an MSP API key
+	// would act on whichever organization JumpCloud picks
+	if config.OrgId.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("org_id"),
+			"Unknown JumpCloud Organization ID",
+			"The provider cannot create the JumpCloud API client as the organization ID is not known until apply. "+
+				"Set org_id to a value known during plan or use the JUMPCLOUD_ORG_ID environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -90,16 +159,96 @@ func (p *JumpCloudProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	api_url := os.Getenv("JUMPCLOUD_API_URL")
+
+	if !config.APIUrl.IsNull() {
+		api_url = config.APIUrl.ValueString()
+	}
+
+	if api_url == "" {
+		api_url = apiclient.JUMPCLOUD_API_BASE_URL
+	}
+
+	if parsed, err := url.Parse(api_url); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Invalid JumpCloud API URL",
+			fmt.Sprintf("The JumpCloud API URL must be an absolute http(s) URL, got: %q", api_url),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	api_url = strings.TrimSuffix(api_url, "/")
+
+	org_id := os.Getenv("JUMPCLOUD_ORG_ID")
+
+	if !config.OrgId.IsNull() {
+		org_id = config.OrgId.ValueString()
+	}
+
+	max_retries := apiclient.DEFAULT_MAX_RETRIES
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		max_retries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retry_max_wait := apiclient.DEFAULT_RETRY_MAX_WAIT
+	if !config.RetryMaxWait.IsNull() && !config.RetryMaxWait.IsUnknown() {
+		retry_max_wait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+	}
+
+	requests_per_second := apiclient.DEFAULT_REQUESTS_PER_SECOND
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		requests_per_second = int(config.RequestsPerSecond.ValueInt64())
+	}
+
+	// Records API traffic for the acceptance tests or replays it without network access
+	recorder_mode := os.Getenv("JUMPCLOUD_RECORDER_MODE")
+	recorder_cassette := os.Getenv("JUMPCLOUD_RECORDER_CASSETTE")
+
+	if recorder_mode != "" && recorder_cassette == "" {
+		resp.Diagnostics.AddError(
+			"Missing JumpCloud Recorder Cassette",
+			"JUMPCLOUD_RECORDER_MODE is set, so JUMPCLOUD_RECORDER_CASSETTE must name the cassette file to record to or replay from.",
+		)
+		return
+	}
+
+	internal := apiclient.New(ctx, api_key, p.version,
+		apiclient.WithBaseUrl(api_url),
+		apiclient.WithOrgId(org_id),
+		apiclient.WithRetry(max_retries, retry_max_wait),
+		apiclient.WithRateLimit(requests_per_second),
+		apiclient.WithRecorder(recorder_mode, recorder_cassette),
+	)
+
+	// The generated clients share the internal client's transport, so they get the same
+	// retry behaviour, draw from the same rate limit and are recorded alongside it
+	v1Config := jcapiv1.NewConfiguration()
+	v1Config.BasePath = api_url
+	v1Config.HTTPClient = internal.HTTPClient()
+
+	v2Config := jcapiv2.NewConfiguration()
+	v2Config.BasePath = api_url + "/v2"
+	v2Config.HTTPClient = internal.HTTPClient()
+
+	if org_id != "" {
+		v1Config.AddDefaultHeader("x-org-id", org_id)
+		v2Config.AddDefaultHeader("x-org-id", org_id)
+	}
+
 	api := JumpCloudApi{
 		V1: api.JumpCloudClientApiV1{
-			Client: jcapiv1.NewAPIClient(jcapiv1.NewConfiguration()),
-			Auth:   context.WithValue(context.TODO(), jcapiv1.ContextAPIKey, jcapiv1.APIKey{Key: api_key}),
+			Client: jcapiv1.NewAPIClient(v1Config),
+			ApiKey: api_key,
 		},
 		V2: api.JumpCloudClientApiV2{
-			Client: jcapiv2.NewAPIClient(jcapiv2.NewConfiguration()),
-			Auth:   context.WithValue(context.TODO(), jcapiv2.ContextAPIKey, jcapiv2.APIKey{Key: api_key}),
+			Client: jcapiv2.NewAPIClient(v2Config),
+			ApiKey: api_key,
 		},
-		Internal: apiclient.New(ctx, api_key, p.version),
+		Internal: internal,
 	}
 
 	resp.DataSourceData = api
@@ -109,13 +258,19 @@ func (p *JumpCloudProvider) Configure(ctx context.Context, req provider.Configur
 func (p *JumpCloudProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewActiveDirectoryResource,
+		NewAssociationResource,
 		NewDeviceGroupResource,
+		NewDeviceGroupMembershipResource,
 		NewUserGroupResource,
+		NewUserGroupMembershipResource,
+		NewUserResource,
 	}
 }
 
 func (p *JumpCloudProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewUserGroupDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -1,16 +1,50 @@
 package jumpcloud
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
 )
 
 func ProviderConfig() string {
-	return "provider \"jumpcloud\" { api_key = \"" + os.Getenv("JUMPCLOUD_API_KEY") + "\" }\n"
+	api_key := os.Getenv("JUMPCLOUD_API_KEY")
+
+	// Replayed cassettes never reach JumpCloud, so no real key is needed
+	if api_key == "" && os.Getenv("JUMPCLOUD_RECORDER_MODE") == apiclient.RECORDER_MODE_REPLAY {
+		api_key = apiclient.RECORDER_REDACTED
+	}
+
+	return "provider \"jumpcloud\" { api_key = \"" + api_key + "\" }\n"
+}
+
+// testAccRecorder points the provider at this test's cassette when JUMPCLOUD_RECORDER_MODE
+// is set to record or replay, see `make testacc-record` and `make testacc-replay`. Replaying
+// a test that has not been recorded yet fails, so a missing cassette is never mistaken for
+// a passing test.
+func testAccRecorder(t *testing.T) {
+	mode := os.Getenv("JUMPCLOUD_RECORDER_MODE")
+	if mode == "" {
+		return
+	}
+
+	cassette := filepath.Join("testdata", "cassettes", t.Name()+".json")
+
+	if _, err := os.Stat(cassette); mode == apiclient.RECORDER_MODE_REPLAY && os.IsNotExist(err) {
+		t.Fatalf("No cassette recorded at %s, run `make testacc-record` first", cassette)
+	}
+
+	t.Setenv("JUMPCLOUD_RECORDER_CASSETTE", cassette)
 }
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -39,3 +73,43 @@ func TestMakeFriendlyName(t *testing.T) {
 		t.Fatalf("Expected %s but got %s", expect, test)
 	}
 }
+
+// Attributes taken from other resources can be unknown while the provider is configured,
+// they must not be treated like unset ones and fall back to a default
+func TestProviderConfigureUnknownValues(t *testing.T) {
+	for _, attribute := range []string{"api_url", "org_id"} {
+		t.Run(attribute, func(t *testing.T) {
+			ctx := context.Background()
+			p := New("test")()
+
+			schema, diags := p.GetSchema(ctx)
+			failOnDiagnostics(t, diags)
+
+			raw := nullAttributes(ctx, schema)
+			raw["api_key"] = tftypes.NewValue(tftypes.String, "test")
+			raw[attribute] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+			resp := provider.ConfigureResponse{}
+			p.Configure(ctx, provider.ConfigureRequest{
+				Config: tfsdk.Config{
+					Schema: schema,
+					Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), raw),
+				},
+			}, &resp)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("Expected an error for an unknown %s", attribute)
+			}
+
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(interface{ Path() path.Path }); !ok || !withPath.Path().Equal(path.Root(attribute)) {
+					t.Errorf("Expected the error to point at %s but got %v", attribute, d)
+				}
+			}
+
+			if resp.ResourceData != nil {
+				t.Errorf("Expected no API client to be configured")
+			}
+		})
+	}
+}
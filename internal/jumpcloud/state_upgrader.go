@@ -0,0 +1,39 @@
+package jumpcloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// upgradeStateFrom builds the resource.StateUpgrader for a prior schema version. The
+// saved state is read with priorSchema into a Prior model and upgrade turns it into the
+// model of the current schema.
+//
+// Terraform runs a single upgrader from the saved version straight to the current one,
+// so when a schema moves on again the existing upgrade functions should be chained
+// (eg v0 -> v1 -> v2) rather than rewritten, each keeping its own frozen prior schema
+// and model.
+func upgradeStateFrom[Prior any, Current any](priorSchema tfsdk.Schema, upgrade func(context.Context, *Prior) (*Current, diag.Diagnostics)) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		PriorSchema: &priorSchema,
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var prior Prior
+
+			resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			current, diags := upgrade(ctx, &prior)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			resp.Diagnostics.Append(resp.State.Set(ctx, current)...)
+		},
+	}
+}
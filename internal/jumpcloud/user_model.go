@@ -0,0 +1,59 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type UserResourceModel struct {
+	Id                   types.String     `tfsdk:"id"`
+	Username             types.String     `tfsdk:"username"`
+	Email                types.String     `tfsdk:"email"`
+	Firstname            types.String     `tfsdk:"firstname"`
+	Middlename           types.String     `tfsdk:"middlename"`
+	Lastname             types.String     `tfsdk:"lastname"`
+	Displayname          types.String     `tfsdk:"displayname"`
+	Password             types.String     `tfsdk:"password"`
+	Description          types.String     `tfsdk:"description"`
+	Sudo                 *SudoConfigModel `tfsdk:"sudo"`
+	Mfa                  *UserMfaModel    `tfsdk:"mfa"`
+	TotpEnabled          types.Bool       `tfsdk:"totp_enabled"`
+	AccountLocked        types.Bool       `tfsdk:"locked"`
+	Suspended            types.Bool       `tfsdk:"suspended"`
+	Activated            types.Bool       `tfsdk:"activated"`
+	PasswordNeverExpires types.Bool       `tfsdk:"password_never_expires"`
+	LdapBindingUser      types.Bool       `tfsdk:"ldap_binding_user"`
+	SambaServiceUser     types.Bool       `tfsdk:"samba_service_user"`
+	UnixUid              types.Int64      `tfsdk:"unix_uid"`
+	UnixGuid             types.Int64      `tfsdk:"unix_guid"`
+	Employee             types.Object     `tfsdk:"employee"`
+	Attributes           types.Map        `tfsdk:"attributes"`
+}
+
+type UserMfaModel struct {
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Exclusion      types.Bool   `tfsdk:"exclusion"`
+	ExclusionUntil types.String `tfsdk:"exclusion_until"`
+}
+
+type EmployeeModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	Type       types.String `tfsdk:"type"`
+	JobTitle   types.String `tfsdk:"job_title"`
+	Company    types.String `tfsdk:"company"`
+	Department types.String `tfsdk:"department"`
+	CostCenter types.String `tfsdk:"cost_center"`
+	Location   types.String `tfsdk:"location"`
+}
+
+func (e EmployeeModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"identifier":  types.StringType,
+		"type":        types.StringType,
+		"job_title":   types.StringType,
+		"company":     types.StringType,
+		"department":  types.StringType,
+		"cost_center": types.StringType,
+		"location":    types.StringType,
+	}
+}
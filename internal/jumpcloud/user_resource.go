@@ -0,0 +1,372 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                = &UserResource{}
+	_ resource.ResourceWithConfigure   = &UserResource{}
+	_ resource.ResourceWithImportState = &UserResource{}
+)
+
+// JumpCloud object ids are MongoDB ObjectIds
+var objectIdPattern = regexp.MustCompile("^[0-9a-fA-F]{24}$")
+
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+type UserResource struct {
+	api *apiclient.Client
+}
+
+func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *UserResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return UserSchema, nil
+}
+
+func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(JumpCloudApi)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.JumpCloudClientApi, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.api = &api.Internal
+}
+
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, diags := convertResourceToUser(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, _, error := r.api.CreateUser(ctx, &user)
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error creating User",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Created new User", map[string]interface{}{
+		"id":       created.Id,
+		"username": created.Username,
+	})
+
+	resp.Diagnostics.Append(r.convertApiResponseToResource(ctx, plan, &created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Refreshing User State from JumpCloud")
+
+	var state *UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, _, error := r.api.GetUserDetails(ctx, state.Id.ValueString())
+	if apiclient.IsNotFound(error) {
+		tflog.Warn(ctx, "User no longer exists on JumpCloud, removing it from state", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retreiving User from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.convertApiResponseToResource(ctx, state, &user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *UserResourceModel
+	var state *UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, diags := convertResourceToUser(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The password is only the initial one, sending it again would reset a password
+	// the user has changed since, so it only goes out when the configuration changes it
+	if plan.Password.Equal(state.Password) {
+		user.Password = ""
+	}
+
+	updated, _, error := r.api.UpdateUser(ctx, &user)
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error updating User on JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.convertApiResponseToResource(ctx, plan, &updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A user that is already gone counts as deleted
+	_, error := r.api.DeleteUser(ctx, state.Id.ValueString())
+	if error != nil && !apiclient.IsNotFound(error) {
+		resp.Diagnostics.AddError(
+			"Error deleting User from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+	}
+}
+
+// ImportState accepts either the user id or the username of an existing user
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if objectIdPattern.MatchString(req.ID) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	user, _, error := r.api.GetUserByUsername(ctx, req.ID)
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error importing User from JumpCloud",
+			fmt.Sprintf("Unable to find user with username %q: %s", req.ID, error),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), user.Id)...)
+}
+
+func (r *UserResource) convertApiResponseToResource(ctx context.Context, resourceModel *UserResourceModel, apiModel *apiclient.User) (diags diag.Diagnostics) {
+	resourceModel.Id = types.StringValue(apiModel.Id)
+	resourceModel.Username = types.StringValue(apiModel.Username)
+	resourceModel.Email = types.StringValue(apiModel.Email)
+	resourceModel.Firstname = types.StringValue(apiModel.Firstname)
+	resourceModel.Middlename = types.StringValue(apiModel.Middlename)
+	resourceModel.Lastname = types.StringValue(apiModel.Lastname)
+	resourceModel.Displayname = types.StringValue(apiModel.Displayname)
+	resourceModel.Description = types.StringValue(apiModel.Description)
+	resourceModel.TotpEnabled = types.BoolValue(apiModel.TotpEnabled)
+	resourceModel.AccountLocked = types.BoolValue(apiModel.AccountLocked)
+	resourceModel.Suspended = types.BoolValue(apiModel.Suspended)
+	resourceModel.Activated = types.BoolValue(apiModel.Activated)
+	resourceModel.PasswordNeverExpires = types.BoolValue(apiModel.PasswordNeverExpires)
+	resourceModel.LdapBindingUser = types.BoolValue(apiModel.LdapBindingUser)
+	resourceModel.SambaServiceUser = types.BoolValue(apiModel.SambaServiceUser)
+	resourceModel.UnixUid = types.Int64Value(apiModel.UnixUid)
+	resourceModel.UnixGuid = types.Int64Value(apiModel.UnixGuid)
+
+	// Optional blocks are only populated when they were configured or when the API
+	// reports a non-default value, otherwise an unconfigured block would always drift
+	if resourceModel.Sudo != nil || apiModel.Sudo || apiModel.PasswordlessSudo {
+		resourceModel.Sudo = &SudoConfigModel{
+			Enabled:      types.BoolValue(apiModel.Sudo),
+			Passwordless: types.BoolValue(apiModel.PasswordlessSudo),
+		}
+	}
+
+	var mfa apiclient.UserMfa
+	if apiModel.Mfa != nil {
+		mfa = *apiModel.Mfa
+	}
+
+	if resourceModel.Mfa != nil || apiModel.EnableUserPortalMultifactor || mfa.Exclusion {
+		resourceModel.Mfa = &UserMfaModel{
+			Enabled:        types.BoolValue(apiModel.EnableUserPortalMultifactor),
+			Exclusion:      types.BoolValue(mfa.Exclusion),
+			ExclusionUntil: types.StringValue(mfa.ExclusionUntil),
+		}
+	}
+
+	hasEmployee := apiModel.EmployeeIdentifier != "" || apiModel.EmployeeType != "" || apiModel.JobTitle != "" ||
+		apiModel.Company != "" || apiModel.Department != "" || apiModel.CostCenter != "" || apiModel.Location != ""
+
+	// The block is computed, so an unconfigured one is null rather than unknown after apply
+	if (!resourceModel.Employee.IsNull() && !resourceModel.Employee.IsUnknown()) || hasEmployee {
+		employee, d := types.ObjectValueFrom(ctx, EmployeeModel{}.AttrTypes(), EmployeeModel{
+			Identifier: types.StringValue(apiModel.EmployeeIdentifier),
+			Type:       types.StringValue(apiModel.EmployeeType),
+			JobTitle:   types.StringValue(apiModel.JobTitle),
+			Company:    types.StringValue(apiModel.Company),
+			Department: types.StringValue(apiModel.Department),
+			CostCenter: types.StringValue(apiModel.CostCenter),
+			Location:   types.StringValue(apiModel.Location),
+		})
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+
+		resourceModel.Employee = employee
+	} else {
+		resourceModel.Employee = types.ObjectNull(EmployeeModel{}.AttrTypes())
+	}
+
+	if len(apiModel.Attributes) > 0 || !resourceModel.Attributes.IsNull() {
+		attributes := make(map[string]string, len(apiModel.Attributes))
+		for _, attribute := range apiModel.Attributes {
+			attributes[attribute.Name] = attribute.Value
+		}
+
+		value, d := types.MapValueFrom(ctx, types.StringType, attributes)
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+
+		resourceModel.Attributes = value
+	}
+
+	// The models carry the password, so only identify the user
+	tflog.Trace(ctx, "Converted User to UserResourceModel", map[string]interface{}{
+		"id":       apiModel.Id,
+		"username": apiModel.Username,
+	})
+
+	return diags
+}
+
+func convertResourceToUser(ctx context.Context, resourceModel *UserResourceModel) (apiModel apiclient.User, diags diag.Diagnostics) {
+	apiModel = apiclient.User{
+		Id:                   resourceModel.Id.ValueString(),
+		Username:             resourceModel.Username.ValueString(),
+		Email:                resourceModel.Email.ValueString(),
+		Firstname:            resourceModel.Firstname.ValueString(),
+		Middlename:           resourceModel.Middlename.ValueString(),
+		Lastname:             resourceModel.Lastname.ValueString(),
+		Displayname:          resourceModel.Displayname.ValueString(),
+		Password:             resourceModel.Password.ValueString(),
+		Description:          resourceModel.Description.ValueString(),
+		AccountLocked:        resourceModel.AccountLocked.ValueBool(),
+		Suspended:            resourceModel.Suspended.ValueBool(),
+		PasswordNeverExpires: resourceModel.PasswordNeverExpires.ValueBool(),
+		LdapBindingUser:      resourceModel.LdapBindingUser.ValueBool(),
+		SambaServiceUser:     resourceModel.SambaServiceUser.ValueBool(),
+		UnixUid:              resourceModel.UnixUid.ValueInt64(),
+		UnixGuid:             resourceModel.UnixGuid.ValueInt64(),
+		Attributes:           []apiclient.UserAttribute{},
+	}
+
+	if resourceModel.Sudo != nil {
+		apiModel.Sudo = resourceModel.Sudo.Enabled.ValueBool()
+		apiModel.PasswordlessSudo = resourceModel.Sudo.Passwordless.ValueBool()
+	}
+
+	if resourceModel.Mfa != nil {
+		apiModel.EnableUserPortalMultifactor = resourceModel.Mfa.Enabled.ValueBool()
+		apiModel.Mfa = &apiclient.UserMfa{
+			Exclusion:      resourceModel.Mfa.Exclusion.ValueBool(),
+			ExclusionUntil: resourceModel.Mfa.ExclusionUntil.ValueString(),
+		}
+	}
+
+	if !resourceModel.Employee.IsNull() && !resourceModel.Employee.IsUnknown() {
+		var employee EmployeeModel
+		diags.Append(resourceModel.Employee.As(ctx, &employee, types.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return apiModel, diags
+		}
+
+		apiModel.EmployeeIdentifier = employee.Identifier.ValueString()
+		apiModel.EmployeeType = employee.Type.ValueString()
+		apiModel.JobTitle = employee.JobTitle.ValueString()
+		apiModel.Company = employee.Company.ValueString()
+		apiModel.Department = employee.Department.ValueString()
+		apiModel.CostCenter = employee.CostCenter.ValueString()
+		apiModel.Location = employee.Location.ValueString()
+	}
+
+	if !resourceModel.Attributes.IsNull() && !resourceModel.Attributes.IsUnknown() {
+		var attributes map[string]string
+		diags.Append(resourceModel.Attributes.ElementsAs(ctx, &attributes, false)...)
+		if diags.HasError() {
+			return apiModel, diags
+		}
+
+		names := make([]string, 0, len(attributes))
+		for name := range attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			apiModel.Attributes = append(apiModel.Attributes, apiclient.UserAttribute{
+				Name:  name,
+				Value: attributes[name],
+			})
+		}
+	}
+
+	return apiModel, diags
+}
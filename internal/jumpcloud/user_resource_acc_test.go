@@ -0,0 +1,77 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserResource(t *testing.T) {
+	testAccRecorder(t)
+
+	test_env := GetTestEnv()
+	username := fmt.Sprintf("terraform-test-user-%s", test_env)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: "jumpcloud_user.test",
+				Config: ProviderConfig() + `
+resource "jumpcloud_user" "test" {
+	username  = "` + username + `"
+	email     = "` + username + `@example.com"
+	firstname = "Terraform"
+	lastname  = "Test"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test", "username", username),
+					resource.TestCheckResourceAttr("jumpcloud_user.test", "firstname", "Terraform"),
+					resource.TestCheckResourceAttrSet("jumpcloud_user.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "jumpcloud_user.test",
+				ImportState:       true,
+				ImportStateId:     username,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "jumpcloud_user.test",
+				Config: ProviderConfig() + `
+resource "jumpcloud_user" "test" {
+	username  = "` + username + `"
+	email     = "` + username + `@example.com"
+	firstname = "Terraform"
+	lastname  = "Updated"
+
+	sudo = {
+		enabled      = true
+		passwordless = false
+	}
+
+	employee = {
+		job_title  = "Tester"
+		department = "Engineering"
+	}
+
+	attributes = {
+		team = "platform"
+	}
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test", "lastname", "Updated"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test", "sudo.enabled", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test", "employee.job_title", "Tester"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test", "attributes.team", "platform"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package jumpcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+)
+
+func newUserModel(username string) UserResourceModel {
+	return UserResourceModel{
+		Id:         types.StringUnknown(),
+		Username:   types.StringValue(username),
+		Email:      types.StringValue(username + "@example.com"),
+		Employee:   types.ObjectNull(EmployeeModel{}.AttrTypes()),
+		Attributes: types.MapNull(types.StringType),
+	}
+}
+
+// planUnconfigured plans the value of an unconfigured optional and computed attribute
+// the way Terraform does for an update, as unknown and then through the plan modifiers
+// of its schema
+func planUnconfigured[T attr.Value](t *testing.T, schema tfsdk.Schema, name string, state T) T {
+	t.Helper()
+
+	ctx := context.Background()
+	attribute := schema.Attributes[name]
+	attributeType := attribute.FrameworkType()
+
+	unknown, err := attributeType.ValueFromTerraform(ctx, tftypes.NewValue(attributeType.TerraformType(ctx), tftypes.UnknownValue))
+	if err != nil {
+		t.Fatalf("Unable to plan %s: %s", name, err)
+	}
+
+	null, err := attributeType.ValueFromTerraform(ctx, tftypes.NewValue(attributeType.TerraformType(ctx), nil))
+	if err != nil {
+		t.Fatalf("Unable to plan %s: %s", name, err)
+	}
+
+	resp := tfsdk.ModifyAttributePlanResponse{AttributePlan: unknown}
+	for _, modifier := range attribute.PlanModifiers {
+		modifier.Modify(ctx, tfsdk.ModifyAttributePlanRequest{
+			AttributePath:   path.Root(name),
+			AttributeConfig: null,
+			AttributeState:  state,
+			AttributePlan:   resp.AttributePlan,
+		}, &resp)
+	}
+
+	planned, ok := resp.AttributePlan.(T)
+	if !ok {
+		t.Fatalf("Unexpected plan value %v for %s", resp.AttributePlan, name)
+	}
+
+	return planned
+}
+
+func TestUserResourceUpdateKeepsConsoleChanges(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewUserResource)
+
+	model := newUserModel("jdoe")
+	model.Password = types.StringValue("Initial-Passw0rd")
+
+	state, diags := createResource(t, r, model)
+	failOnDiagnostics(t, diags)
+
+	var created UserResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	// The user picks their own password and an administrator locks and suspends the account
+	server.Edit(fakeserver.SystemUsers, created.Id.ValueString(), fakeserver.Object{
+		"password":       "Chosen-By-User1",
+		"account_locked": true,
+		"suspended":      true,
+	})
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var refreshed UserResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &refreshed))
+
+	schema := resourceSchema(t, r)
+
+	// Only the last name changes, locked and suspended are not configured
+	plan := refreshed
+	plan.Lastname = types.StringValue("Doe")
+	plan.AccountLocked = planUnconfigured(t, schema, "locked", refreshed.AccountLocked)
+	plan.Suspended = planUnconfigured(t, schema, "suspended", refreshed.Suspended)
+
+	state, diags = updateResource(t, r, state, plan)
+	failOnDiagnostics(t, diags)
+
+	user, _ := server.Get(fakeserver.SystemUsers, created.Id.ValueString())
+
+	if _, sent := user["password"]; sent {
+		t.Errorf("Expected the unchanged password not to be sent again but got %v", user["password"])
+	}
+
+	if user["account_locked"] != true || user["suspended"] != true {
+		t.Errorf("Expected the user to stay locked and suspended but got %v and %v", user["account_locked"], user["suspended"])
+	}
+
+	if user["lastname"] != "Doe" {
+		t.Errorf("Expected the last name to be updated but got %v", user["lastname"])
+	}
+
+	// A new password in the configuration is sent
+	var updated UserResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &updated))
+
+	updated.Password = types.StringValue("Rotated-Passw0rd")
+
+	_, diags = updateResource(t, r, state, updated)
+	failOnDiagnostics(t, diags)
+
+	user, _ = server.Get(fakeserver.SystemUsers, created.Id.ValueString())
+
+	if user["password"] != "Rotated-Passw0rd" {
+		t.Errorf("Expected the changed password to be sent but got %v", user["password"])
+	}
+}
+
+func TestUserResourceUpdateKeepsUnconfiguredAttributes(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewUserResource)
+
+	state, diags := createResource(t, r, newUserModel("jdoe"))
+	failOnDiagnostics(t, diags)
+
+	var created UserResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	// An administrator fills in the profile in the console
+	console := fakeserver.Object{
+		"firstname":              "Jane",
+		"middlename":             "Q",
+		"lastname":               "Doe",
+		"displayname":            "Jane Doe",
+		"description":            "Platform team",
+		"password_never_expires": true,
+		"ldap_binding_user":      true,
+		"samba_service_user":     true,
+		"unix_uid":               float64(5001),
+		"unix_guid":              float64(5001),
+		"jobTitle":               "Engineer",
+		"department":             "Engineering",
+	}
+	server.Edit(fakeserver.SystemUsers, created.Id.ValueString(), console)
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var refreshed UserResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &refreshed))
+
+	schema := resourceSchema(t, r)
+
+	// Only the email is configured to change, nothing else the console set is configured
+	plan := refreshed
+	plan.Email = types.StringValue("jane.doe@example.com")
+	plan.Firstname = planUnconfigured(t, schema, "firstname", refreshed.Firstname)
+	plan.Middlename = planUnconfigured(t, schema, "middlename", refreshed.Middlename)
+	plan.Lastname = planUnconfigured(t, schema, "lastname", refreshed.Lastname)
+	plan.Displayname = planUnconfigured(t, schema, "displayname", refreshed.Displayname)
+	plan.Description = planUnconfigured(t, schema, "description", refreshed.Description)
+	plan.PasswordNeverExpires = planUnconfigured(t, schema, "password_never_expires", refreshed.PasswordNeverExpires)
+	plan.LdapBindingUser = planUnconfigured(t, schema, "ldap_binding_user", refreshed.LdapBindingUser)
+	plan.SambaServiceUser = planUnconfigured(t, schema, "samba_service_user", refreshed.SambaServiceUser)
+	plan.UnixUid = planUnconfigured(t, schema, "unix_uid", refreshed.UnixUid)
+	plan.UnixGuid = planUnconfigured(t, schema, "unix_guid", refreshed.UnixGuid)
+	plan.Employee = planUnconfigured(t, schema, "employee", refreshed.Employee)
+
+	_, diags = updateResource(t, r, state, plan)
+	failOnDiagnostics(t, diags)
+
+	user, _ := server.Get(fakeserver.SystemUsers, created.Id.ValueString())
+
+	if user["email"] != "jane.doe@example.com" {
+		t.Errorf("Expected the email to be updated but got %v", user["email"])
+	}
+
+	for name, value := range console {
+		if user[name] != value {
+			t.Errorf("Expected %s set in the console to stay %v but got %v", name, value, user[name])
+		}
+	}
+}
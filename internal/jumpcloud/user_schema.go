@@ -0,0 +1,283 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var UserSchema = tfsdk.Schema{
+	MarkdownDescription: "JumpCloud System User",
+	Description:         "JumpCloud System User",
+	Version:             0,
+
+	Attributes: map[string]tfsdk.Attribute{
+		"id": {
+			Computed:            true,
+			MarkdownDescription: "Resource ID (Computed / Read-Only)",
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+			Type: types.StringType,
+		},
+		"username": {
+			MarkdownDescription: "Username for the User",
+			Type:                types.StringType,
+			Required:            true,
+		},
+		"email": {
+			MarkdownDescription: "E-Mail Address for the User",
+			Type:                types.StringType,
+			Required:            true,
+		},
+		"firstname": {
+			MarkdownDescription: "First name of the User",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"middlename": {
+			MarkdownDescription: "Middle name of the User",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"lastname": {
+			MarkdownDescription: "Last name of the User",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"displayname": {
+			MarkdownDescription: "Preferred display name of the User",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"password": {
+			MarkdownDescription: "Initial password for the User, only sent when the User is created or this value changes. JumpCloud never returns the password, so changes made outside of Terraform are not detected",
+			Type:                types.StringType,
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"description": {
+			MarkdownDescription: "Description for the User",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"sudo": {
+			MarkdownDescription: "Global sudo configuration for the user",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"enabled": {
+					MarkdownDescription: "Whether this user is allowed to use sudo on every system they are bound to",
+					Type:                types.BoolType,
+					Required:            true,
+				},
+				"passwordless": {
+					MarkdownDescription: "Whether this user will be able to use sudo without entering a password",
+					Type:                types.BoolType,
+					Required:            true,
+				},
+			}),
+			Optional: true,
+		},
+		"mfa": {
+			MarkdownDescription: "Multi-factor authentication settings for the user",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"enabled": {
+					MarkdownDescription: "Whether MFA is required to log into the User Portal",
+					Type:                types.BoolType,
+					Required:            true,
+				},
+				"exclusion": {
+					MarkdownDescription: "Whether the user is temporarily excluded from MFA enforcement",
+					Type:                types.BoolType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"exclusion_until": {
+					MarkdownDescription: "RFC3339 timestamp at which the MFA exclusion expires",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+			}),
+			Optional: true,
+		},
+		"totp_enabled": {
+			MarkdownDescription: "Whether the user has enrolled a TOTP device (Computed / Read-Only)",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"locked": {
+			MarkdownDescription: "Whether the user account is locked. Changes made outside of Terraform are kept unless it is configured.",
+			Type:                types.BoolType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"suspended": {
+			MarkdownDescription: "Whether the user account is suspended. Changes made outside of Terraform are kept unless it is configured.",
+			Type:                types.BoolType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"activated": {
+			MarkdownDescription: "Whether the user has activated their account (Computed / Read-Only)",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"password_never_expires": {
+			MarkdownDescription: "Whether the user's password is exempt from the organization password expiration policy",
+			Type:                types.BoolType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"ldap_binding_user": {
+			MarkdownDescription: "Whether the user is allowed to bind to the JumpCloud LDAP directory",
+			Type:                types.BoolType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"samba_service_user": {
+			MarkdownDescription: "Whether the user is a Samba service user",
+			Type:                types.BoolType,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"unix_uid": {
+			MarkdownDescription: "The UNIX user id of the user",
+			Type:                types.Int64Type,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"unix_guid": {
+			MarkdownDescription: "The UNIX group id of the user",
+			Type:                types.Int64Type,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"employee": {
+			MarkdownDescription: "Employment details for the user",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"identifier": {
+					MarkdownDescription: "The employee identifier",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"type": {
+					MarkdownDescription: "The employee type (eg Contractor, Full Time)",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"job_title": {
+					MarkdownDescription: "The job title",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"company": {
+					MarkdownDescription: "The company",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"department": {
+					MarkdownDescription: "The department",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"cost_center": {
+					MarkdownDescription: "The cost center",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+				"location": {
+					MarkdownDescription: "The work location",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: tfsdk.AttributePlanModifiers{
+						resource.UseStateForUnknown(),
+					},
+				},
+			}),
+			Optional: true,
+			Computed: true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
+		},
+		"attributes": {
+			MarkdownDescription: "Map of custom attributes to set on the user",
+			Type: types.MapType{
+				ElemType: types.StringType,
+			},
+			Optional: true,
+		},
+	},
+}
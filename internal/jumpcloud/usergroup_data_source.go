@@ -0,0 +1,174 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ datasource.DataSource                     = &UserGroupDataSource{}
+	_ datasource.DataSourceWithConfigure        = &UserGroupDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &UserGroupDataSource{}
+)
+
+func NewUserGroupDataSource() datasource.DataSource {
+	return &UserGroupDataSource{}
+}
+
+type UserGroupDataSource struct {
+	api *apiclient.Client
+}
+
+func (d *UserGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usergroup"
+}
+
+func (d *UserGroupDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return UserGroupDataSourceSchema, nil
+}
+
+func (d *UserGroupDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *UserGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(JumpCloudApi)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *JumpCloudClientApi, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.api = &api.Internal
+}
+
+func (d *UserGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UserGroupDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var group apiclient.UserGroup
+	var err error
+
+	if !config.Id.IsNull() {
+		group, _, err = d.api.GetUserGroupDetails(ctx, config.Id.ValueString())
+	} else {
+		group, _, err = d.api.GetUserGroupByName(ctx, config.Name.ValueString())
+	}
+
+	if apiclient.IsNotFound(err) {
+		if !config.Id.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"User Group Not Found",
+				fmt.Sprintf("No User Group with id %q exists on JumpCloud", config.Id.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"User Group Not Found",
+				fmt.Sprintf("No User Group named %q exists on JumpCloud", config.Name.ValueString()),
+			)
+		}
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving User Group from JumpCloud",
+			fmt.Sprintf("API Error: %s", err),
+		)
+
+		return
+	}
+
+	tflog.Info(ctx, "Read User Group from JumpCloud", map[string]interface{}{
+		"id":   group.Id,
+		"name": group.Name,
+	})
+
+	state, diags := convertUserGroupToDataSource(ctx, &group)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// convertUserGroupToDataSource reuses the conversion of the resource. Settings that are
+// not set on JumpCloud are populated rather than null, so that they can be referenced.
+func convertUserGroupToDataSource(ctx context.Context, apiModel *apiclient.UserGroup) (*UserGroupDataSourceModel, diag.Diagnostics) {
+	model, diags := convertUserGroupToResource(ctx, apiModel)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if model.Sudo == nil {
+		model.Sudo = &SudoConfigModel{
+			Enabled:      types.BoolValue(false),
+			Passwordless: types.BoolValue(false),
+		}
+	}
+
+	if model.PosixGroups == nil {
+		model.PosixGroups = []PosixGroupModel{}
+	}
+
+	if model.RadiusReplies == nil {
+		model.RadiusReplies = []KVItemModel{}
+	}
+
+	if model.Properties == nil {
+		model.Properties = []KVItemModel{}
+	}
+
+	if model.MemberQuery != nil && model.MemberQuery.Include == nil {
+		model.MemberQuery.Include = []types.String{}
+	}
+
+	if model.MemberQuery != nil && model.MemberQuery.Exclude == nil {
+		model.MemberQuery.Exclude = []types.String{}
+	}
+
+	return &UserGroupDataSourceModel{
+		Id:                      model.Id,
+		Name:                    model.Name,
+		Sudo:                    model.Sudo,
+		Ldap:                    model.Ldap,
+		PosixGroups:             model.PosixGroups,
+		RadiusReplies:           model.RadiusReplies,
+		Samba:                   model.Samba,
+		Properties:              model.Properties,
+		Description:             model.Description,
+		Email:                   model.Email,
+		MemberQuery:             model.MemberQuery,
+		MemberSuggestionsNotify: model.MemberSuggestionsNotify,
+		MembershipAutomated:     model.MembershipAutomated,
+	}, diags
+}
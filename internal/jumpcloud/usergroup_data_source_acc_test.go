@@ -0,0 +1,42 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserGroupDataSource(t *testing.T) {
+	testAccRecorder(t)
+
+	test_env := GetTestEnv()
+	group_name := fmt.Sprintf("terraform-test-usergroup-data-%s", test_env)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ProviderConfig() + `
+resource "jumpcloud_usergroup" "test" {
+	name        = "` + group_name + `"
+	description = "Looked up by the data sources"
+}
+
+data "jumpcloud_usergroup" "by_name" {
+	name = jumpcloud_usergroup.test.name
+}
+
+data "jumpcloud_usergroup" "by_id" {
+	id = jumpcloud_usergroup.test.id
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.jumpcloud_usergroup.by_name", "id", "jumpcloud_usergroup.test", "id"),
+					resource.TestCheckResourceAttr("data.jumpcloud_usergroup.by_name", "description", "Looked up by the data sources"),
+					resource.TestCheckResourceAttr("data.jumpcloud_usergroup.by_id", "name", group_name),
+					resource.TestCheckResourceAttr("data.jumpcloud_usergroup.by_id", "samba", "false"),
+				),
+			},
+		},
+	})
+}
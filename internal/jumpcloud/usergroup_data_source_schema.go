@@ -0,0 +1,182 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var UserGroupDataSourceSchema = tfsdk.Schema{
+	MarkdownDescription: "Looks up an existing JumpCloud User Group by `id` or by its exact `name`",
+	Description:         "Looks up an existing JumpCloud User Group by id or by its exact name",
+
+	Attributes: map[string]tfsdk.Attribute{
+		"id": {
+			MarkdownDescription: "ID of the User Group, either `id` or `name` has to be set",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+		},
+		"name": {
+			MarkdownDescription: "Exact name of the User Group, either `id` or `name` has to be set. It is an error when no or several User Groups have this name.",
+			Type:                types.StringType,
+			Optional:            true,
+			Computed:            true,
+		},
+		"sudo": {
+			MarkdownDescription: "Sudo configuration for the user-group",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"enabled": {
+					MarkdownDescription: "Whether this user-group will allowed to use sudo",
+					Type:                types.BoolType,
+					Computed:            true,
+				},
+				"passwordless": {
+					MarkdownDescription: "Whether members of this user-group will be able to use sudo without entering a password",
+					Type:                types.BoolType,
+					Computed:            true,
+				},
+			}),
+			Computed: true,
+		},
+		"ldap": {
+			MarkdownDescription: "List of LDAP Groups the user-group is mapped to",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"groups": {
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+						"name": {
+							MarkdownDescription: "The LDAP Group Name",
+							Type:                types.StringType,
+							Computed:            true,
+						},
+					}),
+					Computed: true,
+				},
+			}),
+			Computed: true,
+		},
+		"posix": {
+			MarkdownDescription: "List of POSIX Groups the user-group is mapped to",
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"id": {
+					MarkdownDescription: "The posix group id",
+					Type:                types.Int64Type,
+					Computed:            true,
+				},
+				"name": {
+					MarkdownDescription: "The posix group name",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+			}),
+			Computed: true,
+		},
+		"radius": {
+			MarkdownDescription: "List of RADIUS Replies associated with the user-group",
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					MarkdownDescription: "The reply name",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+				"value": {
+					MarkdownDescription: "The reply value",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+			}),
+			Computed: true,
+		},
+		"samba": {
+			MarkdownDescription: "Whether samba propogation is enabled for this user-group",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"properties": {
+			MarkdownDescription: "List of custom attributes set on the user-group, values that are not strings on JumpCloud are shown JSON encoded",
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					MarkdownDescription: "The property name",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+				"value": {
+					MarkdownDescription: "The property value",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+			}),
+			Computed: true,
+		},
+		"description": {
+			MarkdownDescription: "Description for the User Group",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"email": {
+			MarkdownDescription: "E-Mail Address for the User Group (Mailing List Group)",
+			Type:                types.StringType,
+			Computed:            true,
+		},
+		"member_query": {
+			MarkdownDescription: "Users matching the query are suggested as members of the user-group, or added automatically when `auto` is set. Null when the group has no member query.",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"type": {
+					MarkdownDescription: "`FilterQuery` or `SearchQuery`",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+				"combine": {
+					MarkdownDescription: "Whether a user has to match all (`and`) or any (`or`) of the filters",
+					Type:                types.StringType,
+					Computed:            true,
+				},
+				"filters": {
+					MarkdownDescription: "Filters a user is matched against",
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+						"field": {
+							MarkdownDescription: "The name of the user field to query",
+							Type:                types.StringType,
+							Computed:            true,
+						},
+						"operator": {
+							MarkdownDescription: "The operator to use for the query",
+							Type:                types.StringType,
+							Computed:            true,
+						},
+						"value": {
+							MarkdownDescription: "The value for the filter expression",
+							Type:                types.StringType,
+							Computed:            true,
+						},
+					}),
+					Computed: true,
+				},
+				"include": {
+					MarkdownDescription: "IDs of users that are members even though they do not match the query",
+					Type: types.SetType{
+						ElemType: types.StringType,
+					},
+					Computed: true,
+				},
+				"exclude": {
+					MarkdownDescription: "IDs of users that are never members even though they match the query",
+					Type: types.SetType{
+						ElemType: types.StringType,
+					},
+					Computed: true,
+				},
+			}),
+			Computed: true,
+		},
+		"notify": {
+			MarkdownDescription: "Whether notifications are sent for new member suggestions that match `member_query`",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+		"auto": {
+			MarkdownDescription: "Whether users matching `member_query` are automatically added to the user-group",
+			Type:                types.BoolType,
+			Computed:            true,
+		},
+	},
+}
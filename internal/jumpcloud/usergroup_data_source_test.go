@@ -0,0 +1,154 @@
+package jumpcloud
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+)
+
+func newUserGroupDataSourceModel(id types.String, name types.String) UserGroupDataSourceModel {
+	return UserGroupDataSourceModel{
+		Id:                      id,
+		Name:                    name,
+		Ldap:                    types.ObjectNull(LdapInfo{}.AttrTypes()),
+		Samba:                   types.BoolNull(),
+		Description:             types.StringNull(),
+		Email:                   types.StringNull(),
+		MemberSuggestionsNotify: types.BoolNull(),
+		MembershipAutomated:     types.BoolNull(),
+	}
+}
+
+func TestUserGroupDataSource(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	d := newFakeDataSource(t, server, NewUserGroupDataSource)
+
+	id := server.Seed(fakeserver.UserGroups, fakeserver.Object{
+		"name":        "engineering",
+		"description": "Everyone building the product",
+		"attributes": map[string]interface{}{
+			"sudo":         map[string]interface{}{"enabled": true, "withoutPassword": true},
+			"ldapGroups":   []interface{}{map[string]interface{}{"name": "eng"}},
+			"sambaEnabled": true,
+			"team":         "platform",
+		},
+		"memberQuery": map[string]interface{}{
+			"queryType": apiclient.MEMBER_QUERY_TYPE_FILTER,
+			"filters":   []interface{}{map[string]interface{}{"field": "department", "operator": "eq", "value": "Engineering"}},
+		},
+		"memberQueryExceptions": []interface{}{
+			map[string]interface{}{"id": "5fa000000000000000000001", "type": "user", "attributes": map[string]interface{}{"membership": apiclient.MEMBER_QUERY_EXCEPTION_INCLUDE}},
+		},
+		"membershipAutomated": true,
+	})["id"].(string)
+	plainId := server.Seed(fakeserver.UserGroups, fakeserver.Object{"name": "plain"})["id"].(string)
+
+	expected := UserGroupDataSourceModel{
+		Id:            types.StringValue(id),
+		Name:          types.StringValue("engineering"),
+		Description:   types.StringValue("Everyone building the product"),
+		Email:         types.StringValue(""),
+		Sudo:          &SudoConfigModel{Enabled: types.BoolValue(true), Passwordless: types.BoolValue(true)},
+		Ldap:          newLdapInfo(t, "eng"),
+		PosixGroups:   []PosixGroupModel{},
+		RadiusReplies: []KVItemModel{},
+		Samba:         types.BoolValue(true),
+		Properties:    []KVItemModel{{Name: types.StringValue("team"), Value: types.StringValue("platform")}},
+		MemberQuery: &UserGroupMemberQueryModel{
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+			Filters: []QueryFilterModel{newMemberQueryFilter("department", "eq", "Engineering")},
+			Include: []types.String{types.StringValue("5fa000000000000000000001")},
+			Exclude: []types.String{},
+		},
+		MemberSuggestionsNotify: types.BoolValue(false),
+		MembershipAutomated:     types.BoolValue(true),
+	}
+
+	for name, config := range map[string]UserGroupDataSourceModel{
+		"by id":   newUserGroupDataSourceModel(types.StringValue(id), types.StringNull()),
+		"by name": newUserGroupDataSourceModel(types.StringNull(), types.StringValue("engineering")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			state, diags := readDataSource(t, d, config)
+			failOnDiagnostics(t, diags)
+
+			var read UserGroupDataSourceModel
+			failOnDiagnostics(t, state.Get(ctx, &read))
+
+			if !reflect.DeepEqual(read, expected) {
+				t.Errorf("Expected the user group\n%+v\nbut got\n%+v", expected, read)
+			}
+		})
+	}
+
+	// Settings that are not set on JumpCloud are still known, so they can be referenced
+	state, diags := readDataSource(t, d, newUserGroupDataSourceModel(types.StringNull(), types.StringValue("plain")))
+	failOnDiagnostics(t, diags)
+
+	var plain UserGroupDataSourceModel
+	failOnDiagnostics(t, state.Get(ctx, &plain))
+
+	if plain.Id.ValueString() != plainId || plain.Sudo == nil || plain.Sudo.Enabled.ValueBool() || plain.Samba.IsNull() || plain.Ldap.IsNull() || plain.PosixGroups == nil || plain.MemberQuery != nil {
+		t.Errorf("Expected the defaults of a plain user group but got %+v", plain)
+	}
+}
+
+func TestUserGroupDataSourceLookupErrors(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	d := newFakeDataSource(t, server, NewUserGroupDataSource)
+
+	server.Seed(fakeserver.UserGroups, fakeserver.Object{"name": "duplicate"})
+	server.Seed(fakeserver.UserGroups, fakeserver.Object{"name": "duplicate"})
+
+	tests := map[string]struct {
+		config  UserGroupDataSourceModel
+		summary string
+		path    path.Path
+	}{
+		"missing id": {
+			config:  newUserGroupDataSourceModel(types.StringValue("5fa0000000000000000000ff"), types.StringNull()),
+			summary: "User Group Not Found",
+			path:    path.Root("id"),
+		},
+		"missing name": {
+			config:  newUserGroupDataSourceModel(types.StringNull(), types.StringValue("missing")),
+			summary: "User Group Not Found",
+			path:    path.Root("name"),
+		},
+		"duplicate name": {
+			config:  newUserGroupDataSourceModel(types.StringNull(), types.StringValue("duplicate")),
+			summary: "Error retrieving User Group from JumpCloud",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := readDataSource(t, d, test.config)
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("Expected the lookup to fail with one error but got %v", diags)
+			}
+
+			var errorPath path.Path
+			if withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath); ok {
+				errorPath = withPath.Path()
+			}
+
+			if diags.Errors()[0].Summary() != test.summary || !errorPath.Equal(test.path) {
+				t.Errorf("Expected %q on %q but got %q on %q", test.summary, test.path, diags.Errors()[0].Summary(), errorPath)
+			}
+		})
+	}
+}
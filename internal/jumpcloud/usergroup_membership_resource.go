@@ -0,0 +1,259 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                = &UserGroupMembershipResource{}
+	_ resource.ResourceWithConfigure   = &UserGroupMembershipResource{}
+	_ resource.ResourceWithImportState = &UserGroupMembershipResource{}
+)
+
+func NewUserGroupMembershipResource() resource.Resource {
+	return &UserGroupMembershipResource{}
+}
+
+type UserGroupMembershipResource struct {
+	api *apiclient.Client
+}
+
+type UserGroupMembershipResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	GroupId types.String `tfsdk:"group_id"`
+	Users   types.Set    `tfsdk:"users"`
+}
+
+func (r *UserGroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usergroup_membership"
+}
+
+func (r *UserGroupMembershipResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Authoritatively manages the users that are members of a User Group. Users that are not listed are removed from the group.",
+		Version:             0,
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Resource ID, same as the group id (Computed / Read-Only)",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+			"group_id": {
+				MarkdownDescription: "ID of the User Group",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"users": {
+				MarkdownDescription: "Set of user ids that are members of the User Group",
+				Type: types.SetType{
+					ElemType: types.StringType,
+				},
+				Required: true,
+			},
+		},
+	}, nil
+}
+
+func (r *UserGroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(JumpCloudApi)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.JumpCloudClientApi, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.api = &api.Internal
+}
+
+func (r *UserGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *UserGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.GroupId
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *UserGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Refreshing User Group Membership State from JumpCloud")
+
+	var state *UserGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, error := r.api.ListUserGroupMembers(ctx, state.GroupId.ValueString())
+	if apiclient.IsNotFound(error) {
+		tflog.Warn(ctx, "User Group no longer exists on JumpCloud, removing its membership from state", map[string]interface{}{
+			"group": state.GroupId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retreiving User Group Members from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	users, diags := types.SetValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = state.GroupId
+	state.Users = users
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *UserGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *UserGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.GroupId
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *UserGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *UserGroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var users []string
+	resp.Diagnostics.Append(state.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the group already removed all of its members
+	current, error := r.api.ListUserGroupMembers(ctx, state.GroupId.ValueString())
+	if apiclient.IsNotFound(error) {
+		return
+	}
+
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retreiving User Group Members from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return
+	}
+
+	// Only the members that are managed by this resource are removed
+	for _, userId := range intersectMembers(current, users) {
+		if _, error := r.api.RemoveUserGroupMember(ctx, state.GroupId.ValueString(), userId); error != nil && !apiclient.IsNotFound(error) {
+			resp.Diagnostics.AddError(
+				"Error removing User from User Group",
+				fmt.Sprintf("Unable to remove user %s from group %s: %s", userId, state.GroupId.ValueString(), error),
+			)
+		}
+	}
+}
+
+func (r *UserGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("group_id"), req, resp)
+}
+
+// reconcile adds and removes members so that the group matches the plan exactly
+func (r *UserGroupMembershipResource) reconcile(ctx context.Context, plan *UserGroupMembershipResourceModel) (diags diag.Diagnostics) {
+	groupId := plan.GroupId.ValueString()
+
+	var desired []string
+	diags.Append(plan.Users.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, error := r.api.ListUserGroupMembers(ctx, groupId)
+	if error != nil {
+		diags.AddError(
+			"Error retreiving User Group Members from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
+		return diags
+	}
+
+	add, remove := diffMembers(current, desired)
+
+	tflog.Info(ctx, "Reconciling User Group Membership", map[string]interface{}{
+		"group":  groupId,
+		"add":    add,
+		"remove": remove,
+	})
+
+	for _, userId := range add {
+		if _, error := r.api.AddUserGroupMember(ctx, groupId, userId); error != nil {
+			diags.AddError(
+				"Error adding User to User Group",
+				fmt.Sprintf("Unable to add user %s to group %s: %s", userId, groupId, error),
+			)
+		}
+	}
+
+	for _, userId := range remove {
+		if _, error := r.api.RemoveUserGroupMember(ctx, groupId, userId); error != nil {
+			diags.AddError(
+				"Error removing User from User Group",
+				fmt.Sprintf("Unable to remove user %s from group %s: %s", userId, groupId, error),
+			)
+		}
+	}
+
+	return diags
+}
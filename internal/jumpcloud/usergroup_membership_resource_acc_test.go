@@ -0,0 +1,68 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserGroupMembershipResource(t *testing.T) {
+	testAccRecorder(t)
+
+	test_env := GetTestEnv()
+	name := fmt.Sprintf("terraform-test-membership-%s", test_env)
+
+	config := func(members string) string {
+		return ProviderConfig() + `
+resource "jumpcloud_usergroup" "test" {
+	name = "` + name + `"
+}
+
+resource "jumpcloud_user" "first" {
+	username = "` + name + `-1"
+	email    = "` + name + `-1@example.com"
+}
+
+resource "jumpcloud_user" "second" {
+	username = "` + name + `-2"
+	email    = "` + name + `-2@example.com"
+}
+
+resource "jumpcloud_usergroup_membership" "test" {
+	group_id = jumpcloud_usergroup.test.id
+	users    = [` + members + `]
+}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("jumpcloud_user.first.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("jumpcloud_usergroup_membership.test", "id", "jumpcloud_usergroup.test", "id"),
+					resource.TestCheckResourceAttr("jumpcloud_usergroup_membership.test", "users.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_usergroup_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("jumpcloud_user.first.id, jumpcloud_user.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_usergroup_membership.test", "users.#", "2"),
+				),
+			},
+			{
+				Config: config("jumpcloud_user.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_usergroup_membership.test", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("jumpcloud_usergroup_membership.test", "users.*", "jumpcloud_user.second", "id"),
+				),
+			},
+		},
+	})
+}
@@ -6,23 +6,37 @@ import (
 )
 
 type UserGroupResourceModel struct {
-	Id                      types.String       `tfsdk:"id"`
-	Name                    types.String       `tfsdk:"name"`
-	Sudo                    *SudoConfigModel   `tfsdk:"sudo"`
-	Ldap                    types.Object       `tfsdk:"ldap"`
-	PosixGroups             []PosixGroupModel  `tfsdk:"posix"`
-	RadiusReplies           []KVItemModel      `tfsdk:"radius"`
-	Samba                   *SambaConfig       `tfsdk:"samba"`
-	Properties              []KVItemModel      `tfsdk:"properties"`
-	Description             types.String       `tfsdk:"description"`
-	Email                   types.String       `tfsdk:"email"`
-	MemberQuery             []MemberQueryModel `tfsdk:"member_queries"`
-	MemberSuggestionsNotify types.Bool         `tfsdk:"notify"`
-	MembershipAutomated     types.Bool         `tfsdk:"auto"`
+	Id                      types.String               `tfsdk:"id"`
+	Name                    types.String               `tfsdk:"name"`
+	Sudo                    *SudoConfigModel           `tfsdk:"sudo"`
+	Ldap                    types.Object               `tfsdk:"ldap"`
+	PosixGroups             []PosixGroupModel          `tfsdk:"posix"`
+	RadiusReplies           []KVItemModel              `tfsdk:"radius"`
+	Samba                   types.Bool                 `tfsdk:"samba"`
+	Properties              []KVItemModel              `tfsdk:"properties"`
+	Description             types.String               `tfsdk:"description"`
+	Email                   types.String               `tfsdk:"email"`
+	MemberQuery             *UserGroupMemberQueryModel `tfsdk:"member_query"`
+	MemberSuggestionsNotify types.Bool                 `tfsdk:"notify"`
+	MembershipAutomated     types.Bool                 `tfsdk:"auto"`
+	Timeouts                types.Object               `tfsdk:"timeouts"`
 }
 
-type SambaConfig struct {
-	Enabled types.Bool `tfsdk:"enabled"`
+// UserGroupDataSourceModel exposes the same attributes as UserGroupResourceModel
+type UserGroupDataSourceModel struct {
+	Id                      types.String               `tfsdk:"id"`
+	Name                    types.String               `tfsdk:"name"`
+	Sudo                    *SudoConfigModel           `tfsdk:"sudo"`
+	Ldap                    types.Object               `tfsdk:"ldap"`
+	PosixGroups             []PosixGroupModel          `tfsdk:"posix"`
+	RadiusReplies           []KVItemModel              `tfsdk:"radius"`
+	Samba                   types.Bool                 `tfsdk:"samba"`
+	Properties              []KVItemModel              `tfsdk:"properties"`
+	Description             types.String               `tfsdk:"description"`
+	Email                   types.String               `tfsdk:"email"`
+	MemberQuery             *UserGroupMemberQueryModel `tfsdk:"member_query"`
+	MemberSuggestionsNotify types.Bool                 `tfsdk:"notify"`
+	MembershipAutomated     types.Bool                 `tfsdk:"auto"`
 }
 
 type LdapInfo struct {
@@ -45,10 +59,12 @@ type LdapGroupModel struct {
 	Name types.String `tfsdk:"name"`
 }
 
-type MemberQueryModel struct {
-	Field    types.String `tfsdk:"field"`
-	Operator types.String `tfsdk:"operator"`
-	Value    types.String `tfsdk:"value"`
+type UserGroupMemberQueryModel struct {
+	Type    types.String       `tfsdk:"type"`
+	Combine types.String       `tfsdk:"combine"`
+	Filters []QueryFilterModel `tfsdk:"filters"`
+	Include []types.String     `tfsdk:"include"`
+	Exclude []types.String     `tfsdk:"exclude"`
 }
 
 type PosixGroupModel struct {
@@ -3,6 +3,8 @@ package jumpcloud
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -12,14 +14,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
-
-	"github.com/davecgh/go-spew/spew"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &UserGroupResource{}
 var _ resource.ResourceWithImportState = &UserGroupResource{}
-var _ resource.ResourceWithImportState = &UserGroupResource{}
+var _ resource.ResourceWithValidateConfig = &UserGroupResource{}
+var _ resource.ResourceWithUpgradeState = &UserGroupResource{}
 
 func NewUserGroupResource() resource.Resource {
 	return &UserGroupResource{}
@@ -37,6 +39,44 @@ func (r *UserGroupResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 	return UserGroupSchema, nil
 }
 
+// UpgradeState migrates state saved by earlier versions of UserGroupSchema, see usergroup_upgrade.go
+func (r *UserGroupResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeStateFrom(userGroupSchemaV0, upgradeUserGroupStateV0ToV2),
+		1: upgradeStateFrom(userGroupSchemaV1, upgradeUserGroupStateV1),
+	}
+}
+
+func (r *UserGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config UserGroupResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.MemberQuery != nil {
+		resp.Diagnostics.Append(validateMemberQuery(config.MemberQuery)...)
+	}
+
+	// Properties are stored as a map on JumpCloud, a second value for a name would be lost
+	names := map[string]bool{}
+	for _, property := range config.Properties {
+		if property.Name.IsUnknown() || property.Name.IsNull() {
+			continue
+		}
+
+		if names[property.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("properties"),
+				"Duplicate Property Name",
+				fmt.Sprintf("The property %q is set more than once", property.Name.ValueString()),
+			)
+		}
+		names[property.Name.ValueString()] = true
+	}
+}
+
 func (r *UserGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -61,36 +101,42 @@ func (r *UserGroupResource) Create(ctx context.Context, req resource.CreateReque
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
-	tflog.Trace(ctx, fmt.Sprintf("Called UserGroupResource.Create with:\n%s", spew.Sdump(plan)))
-
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	usergroup := convertResourceToUserGroup(ctx, plan)
+	ctx, cancel := timeouts.WithTimeout(ctx, plan.Timeouts, timeouts.CREATE)
+	defer cancel()
 
-	tflog.Debug(ctx, fmt.Sprintf("Calling GroupsSystemPost with\n%s", spew.Sdump(usergroup)))
+	usergroup, diags := convertResourceToUserGroup(ctx, plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	group, response, error := r.api.CreateUserGroup(&usergroup)
-	tflog.Info(ctx, fmt.Sprintf("JumpCloud API Response: %s\n", r.api.ReadBody(response.Body)))
+	group, _, error := r.api.CreateUserGroup(ctx, &usergroup)
 
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error creating User Group",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 		return
 	}
 
-	var created *UserGroupResourceModel = &UserGroupResourceModel{}
+	created, diags := convertUserGroupToResource(ctx, &group)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	created.Timeouts = plan.Timeouts
 
-	r.convertApiResponseToResource(ctx, created, &group)
 	tflog.Info(ctx, "Created new User Group", map[string]interface{}{
-		"api_response":   "\n\n" + spew.Sdump(group) + "\n\n",
-		"resource_model": "\n\n" + spew.Sdump(created) + "\n\n",
+		"id":   group.Id,
+		"name": group.Name,
 	})
 
-	var diags = resp.State.Set(ctx, created)
+	diags = resp.State.Set(ctx, created)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -108,22 +154,37 @@ func (r *UserGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	group, _, error := r.api.GetUserGroupDetails(plan.Id.ValueString())
+	ctx, cancel := timeouts.WithTimeout(ctx, plan.Timeouts, timeouts.READ)
+	defer cancel()
+
+	group, _, error := r.api.GetUserGroupDetails(ctx, plan.Id.ValueString())
+
+	if apiclient.IsNotFound(error) {
+		tflog.Warn(ctx, "User Group no longer exists on JumpCloud, removing it from state", map[string]interface{}{
+			"id": plan.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
 
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error retreiving User Group from JumpCloud",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
 
-	// tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response.Body))
-
-	r.convertApiResponseToResource(ctx, plan, &group)
+	refreshed, diags := convertUserGroupToResource(ctx, &group)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	refreshed.Timeouts = plan.Timeouts
 
-	diags = resp.State.Set(ctx, plan)
+	diags = resp.State.Set(ctx, refreshed)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -141,33 +202,51 @@ func (r *UserGroupResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	tflog.Trace(ctx, "Evaluated Plan", map[string]interface{}{
-		"UserGroupResourceModel": spew.Sdump(updatePlan),
-	})
+	ctx, cancel := timeouts.WithTimeout(ctx, updatePlan.Timeouts, timeouts.UPDATE)
+	defer cancel()
 
-	tflog.Info(ctx, "Refreshing User Group State from JumpCloud")
-	apiModel := convertResourceToUserGroup(ctx, updatePlan)
+	// The current custom attributes decide which properties are sent decoded from JSON
+	current, _, error := r.api.GetUserGroupDetails(ctx, updatePlan.Id.ValueString())
 
-	updatedApiModel, response, error := r.api.UpdateUserGroup(&apiModel)
+	if error != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving User Group from JumpCloud",
+			fmt.Sprintf("API Error: %s", error),
+		)
 
-	tflog.Trace(ctx, "Got response from JumpCloud API", map[string]interface{}{
-		"Response":  r.api.ReadBody(response.Body),
-		"UserGroup": spew.Sdump(updatedApiModel),
-		"Error":     spew.Sdump(error),
-	})
+		return
+	}
+
+	var currentCustom map[string]interface{}
+	if current.Attributes != nil {
+		currentCustom = current.Attributes.Custom
+	}
+
+	apiModel, diags := convertResourceToUserGroup(ctx, updatePlan, currentCustom)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedApiModel, _, error := r.api.UpdateUserGroup(ctx, &apiModel)
 
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error updating User Group on JumpCloud",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
 
-	r.convertApiResponseToResource(ctx, updatePlan, &updatedApiModel)
+	updated, diags := convertUserGroupToResource(ctx, &updatedApiModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updated.Timeouts = updatePlan.Timeouts
 
-	diags = resp.State.Set(ctx, updatePlan)
+	diags = resp.State.Set(ctx, updated)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -182,26 +261,36 @@ func (r *UserGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	response, error := r.api.DeleteUserGroup(state.Id.ValueString())
-	tflog.Info(ctx, fmt.Sprintf("JumpCloud API Response: %s\n", r.api.ReadBody(response.Body)))
+	ctx, cancel := timeouts.WithTimeout(ctx, state.Timeouts, timeouts.DELETE)
+	defer cancel()
+
+	_, error := r.api.DeleteUserGroup(ctx, state.Id.ValueString())
+
+	// Already deleted outside of Terraform
+	if apiclient.IsNotFound(error) {
+		return
+	}
 
 	if error != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting User Group from JumpCloud",
-			fmt.Sprintf("API Error: %s", spew.Sdump(error)),
+			fmt.Sprintf("API Error: %s", error),
 		)
 
 		return
 	}
-
-	tflog.Trace(ctx, "JumpCloud API Response: \n"+spew.Sdump(response.Body))
 }
 
 func (r *UserGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func (r *UserGroupResource) convertApiResponseToResource(ctx context.Context, resourceModel *UserGroupResourceModel, apiModel *apiclient.UserGroup) (diags diag.Diagnostics) {
+// convertUserGroupToResource converts a user group read from JumpCloud to the model of the
+// resource and the data source, so that a refresh shows every change made on JumpCloud. The
+// timeouts are left to the caller. Blocks and lists JumpCloud reports empty are null, the
+// schema does not accept empty ones so that they match the configuration.
+func convertUserGroupToResource(ctx context.Context, apiModel *apiclient.UserGroup) (resourceModel *UserGroupResourceModel, diags diag.Diagnostics) {
+	resourceModel = &UserGroupResourceModel{}
 	resourceModel.Id = types.StringValue(apiModel.Id)
 	resourceModel.Name = types.StringValue(apiModel.Name)
 	resourceModel.Description = types.StringValue(apiModel.Description)
@@ -209,86 +298,74 @@ func (r *UserGroupResource) convertApiResponseToResource(ctx context.Context, re
 	resourceModel.MemberSuggestionsNotify = types.BoolValue(apiModel.MemberSuggestionsNotify)
 	resourceModel.MembershipAutomated = types.BoolValue(apiModel.MembershipAutomated)
 
+	var attributes apiclient.UserGroupAttributes
 	if apiModel.Attributes != nil {
-		if apiModel.Attributes.SambaEnabled {
-			resourceModel.Samba.Enabled = types.BoolValue(apiModel.Attributes.SambaEnabled)
-		}
-
-		if apiModel.Attributes.Sudo != nil {
-			resourceModel.Sudo.Enabled = types.BoolValue(apiModel.Attributes.Sudo.Enabled)
-			resourceModel.Sudo.Passwordless = types.BoolValue(apiModel.Attributes.Sudo.WithoutPassword)
-		}
+		attributes = *apiModel.Attributes
+	}
 
-		for _, ldapGroup := range apiModel.Attributes.LdapGroups {
-			var ldapInfo LdapInfo
-			if resourceModel.Ldap.IsNull() || resourceModel.Ldap.IsUnknown() {
-				ldapInfo = LdapInfo{}
-			} else {
-				diags := resourceModel.Ldap.As(ctx, ldapInfo, types.ObjectAsOptions{
-					UnhandledNullAsEmpty:    true,
-					UnhandledUnknownAsEmpty: true,
-				})
-
-				if diags.HasError() {
-					tflog.Error(ctx, "Encountered error attempting to retrieve LdapInfo as Object from Type", map[string]interface{}{
-						"IsNull":    resourceModel.Ldap.IsNull(),
-						"IsUnknown": resourceModel.Ldap.IsUnknown(),
-					})
-					return diags
-				}
-			}
+	resourceModel.Samba = types.BoolValue(attributes.SambaEnabled)
 
-			ldapInfo.LdapGroups = append(ldapInfo.LdapGroups, LdapGroupModel{
-				Name: types.StringValue(ldapGroup.Name),
-			})
+	if attributes.Sudo != nil {
+		resourceModel.Sudo = &SudoConfigModel{
+			Enabled:      types.BoolValue(attributes.Sudo.Enabled),
+			Passwordless: types.BoolValue(attributes.Sudo.WithoutPassword),
+		}
+	}
 
-			tflog.Trace(ctx, "Setting LdapInfo as types.Object on plan", map[string]interface{}{
-				"LdapInfo": spew.Sdump(ldapInfo),
-			})
+	// ldap is computed, so it must be known once the group has been read
+	ldapInfo := LdapInfo{
+		LdapGroups: []LdapGroupModel{},
+	}
 
-			ldap, d := types.ObjectValueFrom(ctx, ldapInfo.AttrTypes(), ldapInfo)
+	for _, ldapGroup := range attributes.LdapGroups {
+		ldapInfo.LdapGroups = append(ldapInfo.LdapGroups, LdapGroupModel{
+			Name: types.StringValue(ldapGroup.Name),
+		})
+	}
 
-			diags.Append(d...)
-			if d.HasError() {
-				return diags
-			}
+	ldap, d := types.ObjectValueFrom(ctx, ldapInfo.AttrTypes(), ldapInfo)
+	diags.Append(d...)
+	if d.HasError() {
+		return nil, diags
+	}
+	resourceModel.Ldap = ldap
 
-			resourceModel.Ldap = ldap
-		}
+	for _, posixGroup := range attributes.PosixGroups {
+		resourceModel.PosixGroups = append(resourceModel.PosixGroups, PosixGroupModel{
+			Id:   types.Int64Value(posixGroup.Id),
+			Name: types.StringValue(posixGroup.Name),
+		})
+	}
 
-		for _, posixGroup := range apiModel.Attributes.PosixGroups {
-			resourceModel.PosixGroups = append(resourceModel.PosixGroups, PosixGroupModel{
-				Id:   types.Int64Value(posixGroup.Id),
-				Name: types.StringValue(posixGroup.Name),
+	if attributes.Radius != nil {
+		for _, radiusReply := range attributes.Radius.Reply {
+			resourceModel.RadiusReplies = append(resourceModel.RadiusReplies, KVItemModel{
+				Name:  types.StringValue(radiusReply.Name),
+				Value: types.StringValue(radiusReply.Value),
 			})
 		}
-
-		if apiModel.Attributes.Radius != nil {
-			for _, radiusReply := range apiModel.Attributes.Radius.Reply {
-				resourceModel.RadiusReplies = append(resourceModel.RadiusReplies, KVItemModel{
-					Name:  types.StringValue(radiusReply.Name),
-					Value: types.StringValue(radiusReply.Value),
-				})
-			}
-		}
 	}
 
-	if apiModel.MemberQuery != nil && len(apiModel.MemberQuery.Filters) > 0 {
-		for _, filter := range apiModel.MemberQuery.Filters {
-			resourceModel.MemberQuery = append(resourceModel.MemberQuery, MemberQueryModel{
-				Field:    types.StringValue(filter.Field),
-				Operator: types.StringValue(filter.Operator),
-				Value:    types.StringValue(filter.Value),
-			})
-		}
+	properties, d := convertUserGroupProperties(attributes.Custom)
+	diags.Append(d...)
+	if d.HasError() {
+		return nil, diags
 	}
+	resourceModel.Properties = properties
 
-	tflog.Trace(ctx, fmt.Sprintf("Converted UserGroup to UserGroupResourceModel:\n\tUserGroup: %s\n\tUserGroupResourceModel: %s", spew.Sdump(apiModel), spew.Sdump(resourceModel)))
+	resourceModel.MemberQuery = convertUserGroupMemberQuery(apiModel)
 
-	return diags
+	tflog.Trace(ctx, "Converted UserGroup to UserGroupResourceModel", map[string]interface{}{
+		"id":   apiModel.Id,
+		"name": apiModel.Name,
+	})
+
+	return resourceModel, diags
 }
 
-func convertResourceToUserGroup(ctx context.Context, resourceModel *UserGroupResourceModel) apiclient.UserGroup {
+// convertResourceToUserGroup converts the model to the group sent to JumpCloud, current
+// holds the custom attributes the group has on JumpCloud and is nil for a new group
+func convertResourceToUserGroup(ctx context.Context, resourceModel *UserGroupResourceModel, current map[string]interface{}) (apiModel apiclient.UserGroup, diags diag.Diagnostics) {
 	var sudoConfig *apiclient.UserGroupSudoConfig
 	if resourceModel.Sudo != nil {
 		sudoConfig = &apiclient.UserGroupSudoConfig{
@@ -301,7 +378,7 @@ func convertResourceToUserGroup(ctx context.Context, resourceModel *UserGroupRes
 
 	if !resourceModel.Ldap.IsNull() {
 		var ldapInfo LdapInfo
-		resourceModel.Ldap.As(ctx, ldapInfo, types.ObjectAsOptions{
+		resourceModel.Ldap.As(ctx, &ldapInfo, types.ObjectAsOptions{
 			UnhandledNullAsEmpty:    true,
 			UnhandledUnknownAsEmpty: true,
 		})
@@ -336,52 +413,281 @@ func convertResourceToUserGroup(ctx context.Context, resourceModel *UserGroupRes
 		}
 	}
 
-	var sambaEnabled bool
-	if resourceModel.Samba != nil {
-		sambaEnabled = resourceModel.Samba.Enabled.Equal(types.BoolValue(true))
+	sambaEnabled := resourceModel.Samba.ValueBool()
+
+	var custom map[string]interface{}
+	for _, property := range resourceModel.Properties {
+		if custom == nil {
+			custom = map[string]interface{}{}
+		}
+
+		value, err := decodeCustomAttribute(property.Value.ValueString(), current, property.Name.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("properties"), "Invalid User Group property", err.Error())
+			continue
+		}
+		custom[property.Name.ValueString()] = value
 	}
 
 	var attributes *apiclient.UserGroupAttributes
-	if sudoConfig != nil || len(ldapGroups) > 0 || len(posixGroups) > 0 || radiusConfig != nil || sambaEnabled {
+	if sudoConfig != nil || len(ldapGroups) > 0 || len(posixGroups) > 0 || radiusConfig != nil || sambaEnabled || custom != nil {
 		attributes = &apiclient.UserGroupAttributes{
 			Sudo:         sudoConfig,
 			LdapGroups:   ldapGroups,
 			PosixGroups:  posixGroups,
 			Radius:       radiusConfig,
 			SambaEnabled: sambaEnabled,
+			Custom:       custom,
+		}
+	}
+
+	memberQuery, exceptions := convertMemberQueryToUserGroup(resourceModel.MemberQuery)
+
+	apiModel = apiclient.UserGroup{
+		Id:                    resourceModel.Id.ValueString(),
+		Name:                  resourceModel.Name.ValueString(),
+		MemberQuery:           memberQuery,
+		MemberQueryExceptions: exceptions,
+		Attributes:            attributes,
+	}
+
+	apiModel.Description = resourceModel.Description.ValueString()
+	apiModel.Email = resourceModel.Email.ValueString()
+
+	apiModel.MemberSuggestionsNotify = resourceModel.MemberSuggestionsNotify.ValueBool()
+	apiModel.MembershipAutomated = resourceModel.MembershipAutomated.ValueBool()
+
+	return apiModel, diags
+}
+
+// convertUserGroupProperties converts the custom attributes of a user group to properties
+// sorted by name
+func convertUserGroupProperties(custom map[string]interface{}) (properties []KVItemModel, diags diag.Diagnostics) {
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := encodeCustomAttribute(custom[name])
+		if err != nil {
+			diags.AddError("Unable to convert User Group property", fmt.Sprintf("Property %s: %s", name, err))
+			return nil, diags
 		}
+
+		properties = append(properties, KVItemModel{
+			Name:  types.StringValue(name),
+			Value: types.StringValue(value),
+		})
 	}
 
-	var memberQuery *apiclient.UserGroupMemberQuery
-	var filterQueries []apiclient.QueryFilter
-	if len(resourceModel.MemberQuery) > 0 {
-		for _, query := range resourceModel.MemberQuery {
-			filterQueries = append(filterQueries, apiclient.QueryFilter{
-				Field:    query.Field.ValueString(),
-				Operator: query.Operator.ValueString(),
-				Value:    query.Value.ValueString(),
+	return properties, diags
+}
+
+// Operators of a FilterQuery and the search operators a SearchQuery uses for them,
+// between and search have no equivalent
+var searchOperators = map[string]string{
+	"eq": "$eq",
+	"ne": "$ne",
+	"gt": "$gt",
+	"lt": "$lt",
+	"ge": "$gte",
+	"le": "$lte",
+	"in": "$in",
+}
+
+func validateMemberQuery(query *UserGroupMemberQueryModel) (diags diag.Diagnostics) {
+	queryPath := path.Root("member_query")
+
+	search := query.Type.ValueString() == apiclient.MEMBER_QUERY_TYPE_SEARCH
+	if !query.Type.IsUnknown() && !search && query.Combine.ValueString() == apiclient.SEARCH_FILTER_OR {
+		diags.AddAttributeError(
+			queryPath.AtName("combine"),
+			"Unsupported Member Query",
+			"The filters of a FilterQuery always have to match all, set type to SearchQuery to match any of them",
+		)
+	}
+
+	for i, filter := range query.Filters {
+		if _, ok := searchOperators[filter.Operator.ValueString()]; search && !filter.Operator.IsUnknown() && !ok {
+			diags.AddAttributeError(
+				queryPath.AtName("filters").AtListIndex(i).AtName("operator"),
+				"Unsupported Member Query",
+				fmt.Sprintf("The %s operator is only supported by a FilterQuery", filter.Operator.ValueString()),
+			)
+		}
+	}
+
+	included := map[string]bool{}
+	for _, id := range query.Include {
+		included[id.ValueString()] = true
+	}
+
+	for _, id := range query.Exclude {
+		if !id.IsUnknown() && included[id.ValueString()] {
+			diags.AddAttributeError(
+				queryPath.AtName("exclude"),
+				"Conflicting Member Query Exception",
+				fmt.Sprintf("User %s cannot be both included and excluded", id.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+func convertMemberQueryToUserGroup(query *UserGroupMemberQueryModel) (*apiclient.UserGroupMemberQuery, []apiclient.UserGroupMemberQueryExceptions) {
+	if query == nil {
+		return nil, nil
+	}
+
+	memberQuery := &apiclient.UserGroupMemberQuery{
+		QueryType: apiclient.MEMBER_QUERY_TYPE_FILTER,
+	}
+
+	if query.Type.ValueString() == apiclient.MEMBER_QUERY_TYPE_SEARCH {
+		memberQuery.QueryType = apiclient.MEMBER_QUERY_TYPE_SEARCH
+
+		combine := apiclient.SEARCH_FILTER_AND
+		if query.Combine.ValueString() == apiclient.SEARCH_FILTER_OR {
+			combine = apiclient.SEARCH_FILTER_OR
+		}
+
+		conditions := []map[string]apiclient.SearchOperands{}
+		for _, filter := range query.Filters {
+			var operand interface{} = filter.Value.ValueString()
+			if filter.Operator.ValueString() == "in" {
+				operand = strings.Split(filter.Value.ValueString(), "|")
+			}
+
+			conditions = append(conditions, map[string]apiclient.SearchOperands{
+				filter.Field.ValueString(): {searchOperators[filter.Operator.ValueString()]: operand},
 			})
 		}
 
-		memberQuery = &apiclient.UserGroupMemberQuery{
-			QueryType: "FilterQuery",
-			Filters:   filterQueries,
+		memberQuery.SearchFilter = map[string][]map[string]apiclient.SearchOperands{combine: conditions}
+	} else {
+		for _, filter := range query.Filters {
+			memberQuery.Filters = append(memberQuery.Filters, apiclient.QueryFilter{
+				Field:    filter.Field.ValueString(),
+				Operator: filter.Operator.ValueString(),
+				Value:    filter.Value.ValueString(),
+			})
 		}
 	}
 
-	apiModel := apiclient.UserGroup{
-		Id:          resourceModel.Id.ValueString(),
-		Name:        resourceModel.Name.ValueString(),
-		MemberQuery: memberQuery,
-		Attributes:  attributes,
+	var exceptions []apiclient.UserGroupMemberQueryExceptions
+	for membership, ids := range map[string][]types.String{
+		apiclient.MEMBER_QUERY_EXCEPTION_INCLUDE: query.Include,
+		apiclient.MEMBER_QUERY_EXCEPTION_EXCLUDE: query.Exclude,
+	} {
+		for _, id := range ids {
+			exceptions = append(exceptions, apiclient.UserGroupMemberQueryExceptions{
+				Id:         id.ValueString(),
+				Type:       "user",
+				Attributes: &apiclient.MemberQueryExceptionAttributes{Membership: membership},
+			})
+		}
 	}
 
-	apiModel.Description = resourceModel.Description.ValueString()
-	apiModel.Email = resourceModel.Email.ValueString()
+	// Keeps the request stable, the order of the exceptions has no meaning
+	sort.Slice(exceptions, func(i, j int) bool {
+		return exceptions[i].Id < exceptions[j].Id
+	})
 
-	apiModel.MemberSuggestionsNotify = resourceModel.MemberSuggestionsNotify.ValueBool()
-	apiModel.MembershipAutomated = resourceModel.MembershipAutomated.ValueBool()
+	return memberQuery, exceptions
+}
+
+// convertUserGroupMemberQuery converts the member query of a user group and its exceptions
+func convertUserGroupMemberQuery(apiModel *apiclient.UserGroup) *UserGroupMemberQueryModel {
+	if apiModel.MemberQuery == nil && len(apiModel.MemberQueryExceptions) == 0 {
+		return nil
+	}
+
+	query := &UserGroupMemberQueryModel{
+		Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+		Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+		Filters: []QueryFilterModel{},
+	}
+
+	if apiModel.MemberQuery != nil && apiModel.MemberQuery.QueryType == apiclient.MEMBER_QUERY_TYPE_SEARCH {
+		query.Type = types.StringValue(apiclient.MEMBER_QUERY_TYPE_SEARCH)
+
+		for _, combine := range []string{apiclient.SEARCH_FILTER_AND, apiclient.SEARCH_FILTER_OR} {
+			conditions, ok := apiModel.MemberQuery.SearchFilter[combine]
+			if !ok {
+				continue
+			}
+
+			query.Combine = types.StringValue(combine)
+			for _, condition := range conditions {
+				query.Filters = append(query.Filters, convertSearchCondition(condition)...)
+			}
+		}
+	} else if apiModel.MemberQuery != nil {
+		for _, filter := range apiModel.MemberQuery.Filters {
+			query.Filters = append(query.Filters, QueryFilterModel{
+				Field:    types.StringValue(filter.Field),
+				Operator: types.StringValue(filter.Operator),
+				Value:    types.StringValue(filter.Value),
+			})
+		}
+	}
+
+	for _, exception := range apiModel.MemberQueryExceptions {
+		if exception.Attributes != nil && exception.Attributes.Membership == apiclient.MEMBER_QUERY_EXCEPTION_INCLUDE {
+			query.Include = append(query.Include, types.StringValue(exception.Id))
+		} else {
+			query.Exclude = append(query.Exclude, types.StringValue(exception.Id))
+		}
+	}
+
+	return query
+}
+
+// convertSearchCondition converts a condition like {"department": {"$eq": "IT"}} to filters
+func convertSearchCondition(condition map[string]apiclient.SearchOperands) (filters []QueryFilterModel) {
+	fields := make([]string, 0, len(condition))
+	for field := range condition {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		operators := make([]string, 0, len(searchOperators))
+		for operator := range searchOperators {
+			operators = append(operators, operator)
+		}
+		sort.Strings(operators)
+
+		for _, operator := range operators {
+			operand, ok := condition[field][searchOperators[operator]]
+			if !ok {
+				continue
+			}
+
+			var value string
+			switch v := operand.(type) {
+			case string:
+				value = v
+			case []interface{}:
+				values := make([]string, 0, len(v))
+				for _, item := range v {
+					values = append(values, fmt.Sprint(item))
+				}
+				value = strings.Join(values, "|")
+			default:
+				value = fmt.Sprint(v)
+			}
+
+			filters = append(filters, QueryFilterModel{
+				Field:    types.StringValue(field),
+				Operator: types.StringValue(operator),
+				Value:    types.StringValue(value),
+			})
+		}
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("Converted UserGroupResourceModel to UserGroup:\n\tUserGroup: %s\n\tUserGroupResourceModel: %s", spew.Sdump(apiModel), spew.Sdump(resourceModel)))
-	return apiModel
+	return filters
 }
@@ -8,6 +8,8 @@ import (
 )
 
 func TestAccUserGroupResource(t *testing.T) {
+	testAccRecorder(t)
+
 	test_env := GetTestEnv()
 	group_name := fmt.Sprintf("terraform-test-usergroup-%s", test_env)
 
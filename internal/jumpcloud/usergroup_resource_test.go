@@ -0,0 +1,695 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/fakeserver"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
+)
+
+func newUserGroupModel(name string) UserGroupResourceModel {
+	return UserGroupResourceModel{
+		Id:       types.StringUnknown(),
+		Name:     types.StringValue(name),
+		Ldap:     types.ObjectUnknown(LdapInfo{}.AttrTypes()),
+		Timeouts: timeouts.Null(),
+	}
+}
+
+func TestUserGroupResourceLifecycle(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewUserGroupResource)
+
+	state, diags := createResource(t, r, newUserGroupModel("engineering"))
+	failOnDiagnostics(t, diags)
+
+	var created UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	if _, ok := server.Get(fakeserver.UserGroups, created.Id.ValueString()); !ok {
+		t.Fatalf("Expected user group %s to exist on the server", created.Id.ValueString())
+	}
+
+	update := newUserGroupModel("engineering-updated")
+	update.Id = created.Id
+
+	state, diags = updateResource(t, r, state, update)
+	failOnDiagnostics(t, diags)
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var read UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &read))
+
+	if read.Name.ValueString() != "engineering-updated" {
+		t.Errorf("Expected name engineering-updated but got %s", read.Name.ValueString())
+	}
+
+	failOnDiagnostics(t, deleteResource(t, r, state))
+
+	if _, ok := server.Get(fakeserver.UserGroups, created.Id.ValueString()); ok {
+		t.Errorf("Expected user group %s to be deleted", created.Id.ValueString())
+	}
+}
+
+func TestUserGroupResourceDeletedOutsideTerraform(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	r := newFakeResource(t, server, NewUserGroupResource)
+
+	state, diags := createResource(t, r, newUserGroupModel("engineering"))
+	failOnDiagnostics(t, diags)
+
+	var created UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(context.Background(), &created))
+
+	server.Remove(fakeserver.UserGroups, created.Id.ValueString())
+
+	failOnDiagnostics(t, deleteResource(t, r, state))
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	if !state.Raw.IsNull() {
+		t.Errorf("Expected the user group to be removed from state")
+	}
+}
+
+func TestUserGroupResourceUnconfiguredDescription(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	schema := resourceSchema(t, newFakeResource(t, server, NewUserGroupResource))
+
+	// A description or email set in the console is kept rather than reset to the default
+	for _, name := range []string{"description", "email"} {
+		if planned := planUnconfigured(t, schema, name, types.StringValue("Set in the console")); !planned.IsUnknown() {
+			t.Errorf("Expected an unconfigured %s to stay unknown but got %v", name, planned)
+		}
+	}
+}
+
+func TestUserGroupResourceProperties(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewUserGroupResource)
+
+	model := newUserGroupModel("engineering")
+	model.Properties = []KVItemModel{
+		{Name: types.StringValue("team"), Value: types.StringValue("platform")},
+		{Name: types.StringValue("costCenter"), Value: types.StringValue("1234")},
+	}
+
+	state, diags := createResource(t, r, model)
+	failOnDiagnostics(t, diags)
+
+	var created UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &created))
+
+	group, _ := server.Get(fakeserver.UserGroups, created.Id.ValueString())
+	attributes, _ := group["attributes"].(map[string]interface{})
+	if attributes["team"] != "platform" || attributes["costCenter"] != "1234" {
+		t.Fatalf("Expected the properties as custom attributes on the server but got %v", group["attributes"])
+	}
+
+	// Properties are a set, they are read back sorted by name
+	expected := []KVItemModel{
+		{Name: types.StringValue("costCenter"), Value: types.StringValue("1234")},
+		{Name: types.StringValue("team"), Value: types.StringValue("platform")},
+	}
+
+	if !reflect.DeepEqual(created.Properties, expected) {
+		t.Errorf("Expected properties %v after create but got %v", expected, created.Properties)
+	}
+
+	// Changes made in the console show up as drift
+	server.Edit(fakeserver.UserGroups, created.Id.ValueString(), fakeserver.Object{
+		"attributes": map[string]interface{}{
+			"costCenter": "5678",
+			"region":     "eu",
+			"priority":   2,
+		},
+	})
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var read UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &read))
+
+	expected = []KVItemModel{
+		{Name: types.StringValue("costCenter"), Value: types.StringValue("5678")},
+		{Name: types.StringValue("priority"), Value: types.StringValue("2")},
+		{Name: types.StringValue("region"), Value: types.StringValue("eu")},
+	}
+
+	if !reflect.DeepEqual(read.Properties, expected) {
+		t.Errorf("Expected properties %v after refresh but got %v", expected, read.Properties)
+	}
+
+	// Removing the properties clears them on JumpCloud
+	update := newUserGroupModel("engineering")
+	update.Id = created.Id
+
+	state, diags = updateResource(t, r, state, update)
+	failOnDiagnostics(t, diags)
+
+	failOnDiagnostics(t, state.Get(ctx, &read))
+	if read.Properties != nil {
+		t.Errorf("Expected no properties after update but got %v", read.Properties)
+	}
+
+	group, _ = server.Get(fakeserver.UserGroups, created.Id.ValueString())
+	if group["attributes"] != nil {
+		t.Errorf("Expected the custom attributes to be removed on the server but got %v", group["attributes"])
+	}
+}
+
+func TestUserGroupResourcePropertiesKeepTheirType(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	ctx := context.Background()
+	r := newFakeResource(t, server, NewUserGroupResource)
+
+	id := server.Seed(fakeserver.UserGroups, fakeserver.Object{
+		"name":       "engineering",
+		"attributes": map[string]interface{}{"priority": 1, "oncall": true, "team": "platform"},
+	})["id"].(string)
+
+	state, diags := importResource(t, r, id)
+	failOnDiagnostics(t, diags)
+
+	state, diags = readResource(t, r, state)
+	failOnDiagnostics(t, diags)
+
+	var imported UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &imported))
+
+	expected := []KVItemModel{
+		{Name: types.StringValue("oncall"), Value: types.StringValue("true")},
+		{Name: types.StringValue("priority"), Value: types.StringValue("1")},
+		{Name: types.StringValue("team"), Value: types.StringValue("platform")},
+	}
+
+	if !reflect.DeepEqual(imported.Properties, expected) {
+		t.Fatalf("Expected properties %v after import but got %v", expected, imported.Properties)
+	}
+
+	// Changing the priority and adding a property that only looks like a number
+	model := imported
+	model.Properties = []KVItemModel{
+		{Name: types.StringValue("oncall"), Value: types.StringValue("true")},
+		{Name: types.StringValue("priority"), Value: types.StringValue("2")},
+		{Name: types.StringValue("team"), Value: types.StringValue("platform")},
+		{Name: types.StringValue("costCenter"), Value: types.StringValue("1234")},
+	}
+
+	_, diags = updateResource(t, r, state, model)
+	failOnDiagnostics(t, diags)
+
+	group, _ := server.Get(fakeserver.UserGroups, id)
+	attributes, _ := group["attributes"].(map[string]interface{})
+
+	if attributes["priority"] != float64(2) || attributes["oncall"] != true || attributes["team"] != "platform" || attributes["costCenter"] != "1234" {
+		t.Errorf("Expected the custom attributes to keep their type on the server but got %v", attributes)
+	}
+
+	// A value that is not JSON cannot replace a number
+	model.Properties = []KVItemModel{{Name: types.StringValue("priority"), Value: types.StringValue("high")}}
+
+	if _, diags = updateResource(t, r, state, model); !diags.HasError() {
+		t.Errorf("Expected a non JSON value for a number property to be rejected")
+	}
+}
+
+func TestUserGroupResourceDuplicateProperties(t *testing.T) {
+	server := fakeserver.New()
+	defer server.Close()
+
+	r := newFakeResource(t, server, NewUserGroupResource)
+
+	model := newUserGroupModel("engineering")
+	model.Properties = []KVItemModel{
+		{Name: types.StringValue("team"), Value: types.StringValue("platform")},
+		{Name: types.StringValue("team"), Value: types.StringValue("security")},
+	}
+
+	if diags := validateConfig(t, r, model); !diags.HasError() {
+		t.Errorf("Expected an error for a property set twice")
+	}
+}
+
+func newMemberQueryFilter(field string, operator string, value string) QueryFilterModel {
+	return QueryFilterModel{
+		Field:    types.StringValue(field),
+		Operator: types.StringValue(operator),
+		Value:    types.StringValue(value),
+	}
+}
+
+func TestUserGroupResourceMemberQuery(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    UserGroupMemberQueryModel
+		expected string
+	}{
+		{
+			name: "filter query",
+			query: UserGroupMemberQueryModel{
+				Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+				Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+				Filters: []QueryFilterModel{
+					newMemberQueryFilter("department", "eq", "Engineering"),
+					newMemberQueryFilter("employeeType", "ne", "Contractor"),
+				},
+				Include: []types.String{types.StringValue("5fa000000000000000000001")},
+				Exclude: []types.String{types.StringValue("5fa000000000000000000002")},
+			},
+			expected: `{"memberQuery":{"filters":[{"field":"department","operator":"eq","value":"Engineering"},{"field":"employeeType","operator":"ne","value":"Contractor"}],"queryType":"FilterQuery"},"memberQueryExceptions":[{"attributes":{"membership":"INCLUDE"},"id":"5fa000000000000000000001","type":"user"},{"attributes":{"membership":"EXCLUDE"},"id":"5fa000000000000000000002","type":"user"}]}`,
+		},
+		{
+			name: "search query",
+			query: UserGroupMemberQueryModel{
+				Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_SEARCH),
+				Combine: types.StringValue(apiclient.SEARCH_FILTER_OR),
+				Filters: []QueryFilterModel{
+					newMemberQueryFilter("department", "in", "IT|Security"),
+					newMemberQueryFilter("unix_uid", "ge", "5000"),
+				},
+			},
+			expected: `{"memberQuery":{"filter":{"or":[{"department":{"$in":["IT","Security"]}},{"unix_uid":{"$gte":"5000"}}]},"queryType":"SearchQuery"},"memberQueryExceptions":null}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := fakeserver.New()
+			defer server.Close()
+
+			ctx := context.Background()
+			r := newFakeResource(t, server, NewUserGroupResource)
+
+			model := newUserGroupModel("engineering")
+			model.MemberQuery = &c.query
+
+			failOnDiagnostics(t, validateConfig(t, r, model))
+
+			state, diags := createResource(t, r, model)
+			failOnDiagnostics(t, diags)
+
+			var created UserGroupResourceModel
+			failOnDiagnostics(t, state.Get(ctx, &created))
+
+			group, _ := server.Get(fakeserver.UserGroups, created.Id.ValueString())
+			sent, _ := json.Marshal(map[string]interface{}{
+				"memberQuery":           group["memberQuery"],
+				"memberQueryExceptions": group["memberQueryExceptions"],
+			})
+
+			if string(sent) != c.expected {
+				t.Errorf("Expected the member query\n%s\non the server but got\n%s", c.expected, sent)
+			}
+
+			state, diags = readResource(t, r, state)
+			failOnDiagnostics(t, diags)
+
+			var read UserGroupResourceModel
+			failOnDiagnostics(t, state.Get(ctx, &read))
+
+			if !reflect.DeepEqual(read.MemberQuery, &c.query) {
+				t.Errorf("Expected member query %v after refresh but got %v", c.query, read.MemberQuery)
+			}
+		})
+	}
+}
+
+func TestUserGroupResourceMemberQueryValidation(t *testing.T) {
+	cases := map[string]UserGroupMemberQueryModel{
+		"or in a filter query": {
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_OR),
+			Filters: []QueryFilterModel{newMemberQueryFilter("department", "eq", "IT")},
+		},
+		"between in a search query": {
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_SEARCH),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+			Filters: []QueryFilterModel{newMemberQueryFilter("unix_uid", "between", "5000|6000")},
+		},
+		"included and excluded": {
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+			Filters: []QueryFilterModel{newMemberQueryFilter("department", "eq", "IT")},
+			Include: []types.String{types.StringValue("5fa000000000000000000001")},
+			Exclude: []types.String{types.StringValue("5fa000000000000000000001")},
+		},
+	}
+
+	server := fakeserver.New()
+	defer server.Close()
+
+	r := newFakeResource(t, server, NewUserGroupResource)
+
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			model := newUserGroupModel("engineering")
+			model.MemberQuery = &query
+
+			if diags := validateConfig(t, r, model); !diags.HasError() {
+				t.Errorf("Expected the member query to be rejected")
+			}
+		})
+	}
+}
+
+func newLdapInfo(t *testing.T, names ...string) types.Object {
+	t.Helper()
+
+	ldapInfo := LdapInfo{LdapGroups: []LdapGroupModel{}}
+	for _, name := range names {
+		ldapInfo.LdapGroups = append(ldapInfo.LdapGroups, LdapGroupModel{Name: types.StringValue(name)})
+	}
+
+	ldap, diags := types.ObjectValueFrom(context.Background(), ldapInfo.AttrTypes(), ldapInfo)
+	failOnDiagnostics(t, diags)
+
+	return ldap
+}
+
+func TestUserGroupResourceConvertApiResponse(t *testing.T) {
+	// Everything a user group can have configured
+	configured := func() UserGroupResourceModel {
+		model := newUserGroupModel("engineering")
+		model.Id = types.StringValue("5fa000000000000000000001")
+		model.Description = types.StringValue("Engineers")
+		model.Email = types.StringValue("eng@example.com")
+		model.MemberSuggestionsNotify = types.BoolValue(true)
+		model.MembershipAutomated = types.BoolValue(true)
+		model.Samba = types.BoolValue(true)
+		model.Sudo = &SudoConfigModel{Enabled: types.BoolValue(true), Passwordless: types.BoolValue(true)}
+		model.Ldap = newLdapInfo(t, "engineers")
+		model.PosixGroups = []PosixGroupModel{{Id: types.Int64Value(1000), Name: types.StringValue("engineers")}}
+		model.RadiusReplies = []KVItemModel{{Name: types.StringValue("Filter-Id"), Value: types.StringValue("eng")}}
+		model.Properties = []KVItemModel{{Name: types.StringValue("costCenter"), Value: types.StringValue("1234")}}
+		model.MemberQuery = &UserGroupMemberQueryModel{
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+			Filters: []QueryFilterModel{newMemberQueryFilter("department", "eq", "Engineering")},
+			Exclude: []types.String{types.StringValue("5fa000000000000000000009")},
+		}
+
+		return model
+	}
+
+	configuredPayload := `{
+		"id": "5fa000000000000000000001",
+		"name": "engineering",
+		"description": "Engineers",
+		"email": "eng@example.com",
+		"memberSuggestionsNotify": true,
+		"membershipAutomated": true,
+		"attributes": {
+			"sambaEnabled": true,
+			"sudo": {"enabled": true, "withoutPassword": true},
+			"ldapGroups": [{"name": "engineers"}],
+			"posixGroups": [{"id": 1000, "name": "engineers"}],
+			"radius": {"reply": [{"name": "Filter-Id", "value": "eng"}]},
+			"costCenter": "1234"
+		},
+		"memberQuery": {
+			"queryType": "FilterQuery",
+			"filters": [{"field": "department", "operator": "eq", "value": "Engineering"}]
+		},
+		"memberQueryExceptions": [{"id": "5fa000000000000000000009", "type": "user"}]
+	}`
+
+	cases := []struct {
+		name     string
+		payload  string
+		expected func() UserGroupResourceModel
+	}{
+		{
+			name:    "nothing set",
+			payload: `{"id": "5fa000000000000000000001", "name": "engineering"}`,
+			expected: func() UserGroupResourceModel {
+				model := newUserGroupModel("engineering")
+				model.Id = types.StringValue("5fa000000000000000000001")
+				model.Description = types.StringValue("")
+				model.Email = types.StringValue("")
+				model.MemberSuggestionsNotify = types.BoolValue(false)
+				model.MembershipAutomated = types.BoolValue(false)
+				model.Samba = types.BoolValue(false)
+				model.Ldap = newLdapInfo(t)
+
+				return model
+			},
+		},
+		{
+			name:     "everything set",
+			payload:  configuredPayload,
+			expected: configured,
+		},
+		{
+			name: "changed on JumpCloud",
+			payload: `{
+				"id": "5fa000000000000000000001",
+				"name": "engineering",
+				"description": "Engineers",
+				"email": "eng@example.com",
+				"memberSuggestionsNotify": true,
+				"membershipAutomated": true,
+				"attributes": {
+					"sambaEnabled": true,
+					"sudo": {"enabled": true},
+					"ldapGroups": [{"name": "developers"}, {"name": "engineers"}],
+					"posixGroups": [{"id": 2000, "name": "developers"}],
+					"radius": {"reply": [{"name": "Filter-Id", "value": "dev"}]},
+					"region": "eu",
+					"costCenter": "1234",
+					"priority": 2
+				},
+				"memberQuery": {
+					"queryType": "FilterQuery",
+					"filters": [{"field": "department", "operator": "ne", "value": "Sales"}]
+				},
+				"memberQueryExceptions": [{"id": "5fa000000000000000000008", "type": "user", "attributes": {"membership": "INCLUDE"}}]
+			}`,
+			expected: func() UserGroupResourceModel {
+				model := configured()
+				model.Sudo.Passwordless = types.BoolValue(false)
+				model.Ldap = newLdapInfo(t, "developers", "engineers")
+				model.PosixGroups = []PosixGroupModel{{Id: types.Int64Value(2000), Name: types.StringValue("developers")}}
+				model.RadiusReplies = []KVItemModel{{Name: types.StringValue("Filter-Id"), Value: types.StringValue("dev")}}
+				model.Properties = []KVItemModel{
+					{Name: types.StringValue("costCenter"), Value: types.StringValue("1234")},
+					{Name: types.StringValue("priority"), Value: types.StringValue("2")},
+					{Name: types.StringValue("region"), Value: types.StringValue("eu")},
+				}
+				model.MemberQuery.Filters = []QueryFilterModel{newMemberQueryFilter("department", "ne", "Sales")}
+				model.MemberQuery.Include = []types.String{types.StringValue("5fa000000000000000000008")}
+				model.MemberQuery.Exclude = nil
+
+				return model
+			},
+		},
+		{
+			name:    "removed on JumpCloud",
+			payload: `{"id": "5fa000000000000000000001", "name": "engineering", "description": "Engineers", "email": "eng@example.com"}`,
+			expected: func() UserGroupResourceModel {
+				model := configured()
+				model.MemberSuggestionsNotify = types.BoolValue(false)
+				model.MembershipAutomated = types.BoolValue(false)
+				model.Samba = types.BoolValue(false)
+				model.Sudo = nil
+				model.Ldap = newLdapInfo(t)
+				model.PosixGroups = nil
+				model.RadiusReplies = nil
+				model.Properties = nil
+				model.MemberQuery = nil
+
+				return model
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var group apiclient.UserGroup
+			if err := json.Unmarshal([]byte(c.payload), &group); err != nil {
+				t.Fatalf("Invalid payload: %s", err)
+			}
+
+			model, diags := convertUserGroupToResource(context.Background(), &group)
+			failOnDiagnostics(t, diags)
+
+			// The timeouts are not reported by JumpCloud, they are left to the caller
+			model.Timeouts = timeouts.Null()
+
+			if expected := c.expected(); !reflect.DeepEqual(*model, expected) {
+				t.Errorf("Expected\n%+v\nbut got\n%+v", expected, model)
+			}
+		})
+	}
+}
+
+func TestUserGroupSchemaVersion(t *testing.T) {
+	// The shape of state saved by each version of UserGroupSchema. Changing the shape needs a
+	// new Version with an upgrader for the previous one, see usergroup_upgrade.go, and its
+	// shape recorded here.
+	shapes := map[int64]string{
+		1: `tftypes.Object["auto":tftypes.Bool, "description":tftypes.String, "email":tftypes.String, "id":tftypes.String, "ldap":tftypes.Object["groups":tftypes.List[tftypes.Object["name":tftypes.String]]], "member_query":tftypes.Object["combine":tftypes.String, "exclude":tftypes.Set[tftypes.String], "filters":tftypes.List[tftypes.Object["field":tftypes.String, "operator":tftypes.String, "value":tftypes.String]], "include":tftypes.Set[tftypes.String], "type":tftypes.String], "name":tftypes.String, "notify":tftypes.Bool, "posix":tftypes.List[tftypes.Object["id":tftypes.Number, "name":tftypes.String]], "properties":tftypes.List[tftypes.Object["name":tftypes.String, "value":tftypes.String]], "radius":tftypes.List[tftypes.Object["name":tftypes.String, "value":tftypes.String]], "samba":tftypes.Bool, "sudo":tftypes.Object["enabled":tftypes.Bool, "passwordless":tftypes.Bool], "timeouts":tftypes.Object["create":tftypes.String, "delete":tftypes.String, "read":tftypes.String, "update":tftypes.String]]`,
+		2: `tftypes.Object["auto":tftypes.Bool, "description":tftypes.String, "email":tftypes.String, "id":tftypes.String, "ldap":tftypes.Object["groups":tftypes.List[tftypes.Object["name":tftypes.String]]], "member_query":tftypes.Object["combine":tftypes.String, "exclude":tftypes.Set[tftypes.String], "filters":tftypes.List[tftypes.Object["field":tftypes.String, "operator":tftypes.String, "value":tftypes.String]], "include":tftypes.Set[tftypes.String], "type":tftypes.String], "name":tftypes.String, "notify":tftypes.Bool, "posix":tftypes.List[tftypes.Object["id":tftypes.Number, "name":tftypes.String]], "properties":tftypes.Set[tftypes.Object["name":tftypes.String, "value":tftypes.String]], "radius":tftypes.List[tftypes.Object["name":tftypes.String, "value":tftypes.String]], "samba":tftypes.Bool, "sudo":tftypes.Object["enabled":tftypes.Bool, "passwordless":tftypes.Bool], "timeouts":tftypes.Object["create":tftypes.String, "delete":tftypes.String, "read":tftypes.String, "update":tftypes.String]]`,
+	}
+
+	shape, ok := shapes[UserGroupSchema.Version]
+	if !ok {
+		t.Fatalf("The shape of version %d of the user group schema is not recorded", UserGroupSchema.Version)
+	}
+
+	if actual := UserGroupSchema.Type().TerraformType(context.Background()).String(); actual != shape {
+		t.Errorf("The user group schema changed without a new Version, expected\n%s\nbut got\n%s", shape, actual)
+	}
+
+	upgraders := (&UserGroupResource{}).UpgradeState(context.Background())
+	for version := int64(0); version < UserGroupSchema.Version; version++ {
+		if _, ok := upgraders[version]; !ok {
+			t.Errorf("Expected an upgrader for version %d of the user group schema", version)
+		}
+	}
+}
+
+func TestUserGroupResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserGroupResource()
+
+	// Saved before the timeouts were added, so it has no timeouts at all
+	rawState := `{
+		"id": "5fa000000000000000000001",
+		"name": "engineering",
+		"description": "",
+		"email": "",
+		"sudo": {"enabled": true, "passwordless": false},
+		"ldap": {"groups": [{"name": "eng"}]},
+		"posix": null,
+		"radius": null,
+		"samba": true,
+		"properties": [{"name": "team", "value": "platform"}],
+		"member_queries": [
+			{"query": {
+				"type": {"field": "employeeType", "operator": "ne", "value": "Contractor"},
+				"department": {"field": "department", "operator": "eq", "value": "Engineering"}
+			}},
+			{"query": {
+				"uid": {"field": "unix_uid", "operator": "ge", "value": "5000"}
+			}}
+		],
+		"notify": true,
+		"auto": false
+	}`
+
+	state, diags := upgradeResourceState(t, r, "jumpcloud_usergroup", 0, rawState)
+	failOnDiagnostics(t, diags)
+
+	var upgraded UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &upgraded))
+
+	expected := UserGroupResourceModel{
+		Id:          types.StringValue("5fa000000000000000000001"),
+		Name:        types.StringValue("engineering"),
+		Description: types.StringValue(""),
+		Email:       types.StringValue(""),
+		Sudo:        &SudoConfigModel{Enabled: types.BoolValue(true), Passwordless: types.BoolValue(false)},
+		Ldap:        newLdapInfo(t, "eng"),
+		Samba:       types.BoolValue(true),
+		Properties:  []KVItemModel{{Name: types.StringValue("team"), Value: types.StringValue("platform")}},
+		MemberQuery: &UserGroupMemberQueryModel{
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+			Filters: []QueryFilterModel{
+				newMemberQueryFilter("department", "eq", "Engineering"),
+				newMemberQueryFilter("employeeType", "ne", "Contractor"),
+				newMemberQueryFilter("unix_uid", "ge", "5000"),
+			},
+		},
+		MemberSuggestionsNotify: types.BoolValue(true),
+		MembershipAutomated:     types.BoolValue(false),
+		Timeouts:                timeouts.Null(),
+	}
+
+	if !reflect.DeepEqual(upgraded, expected) {
+		t.Errorf("Expected the upgraded state\n%+v\nbut got\n%+v", expected, upgraded)
+	}
+
+	// An upgraded group without member queries has no member_query either
+	state, diags = upgradeResourceState(t, r, "jumpcloud_usergroup", 0, `{"id": "5fa000000000000000000001", "name": "engineering", "member_queries": null, "samba": false}`)
+	failOnDiagnostics(t, diags)
+
+	failOnDiagnostics(t, state.Get(ctx, &upgraded))
+
+	if upgraded.MemberQuery != nil || upgraded.Samba != types.BoolValue(false) {
+		t.Errorf("Expected only samba to be upgraded but got member query %v and samba %v", upgraded.MemberQuery, upgraded.Samba)
+	}
+
+	// State that does not match version 0 is reported rather than dropped
+	_, diags = upgradeResourceState(t, r, "jumpcloud_usergroup", 0, `{"id": "5fa000000000000000000001", "name": "engineering", "samba": {"enabled": true}}`)
+
+	if !diags.HasError() {
+		t.Errorf("Expected an error for state that does not match version 0")
+	}
+}
+
+func TestUserGroupResourceUpgradeStateV1(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserGroupResource()
+
+	rawState := `{
+		"id": "5fa000000000000000000001",
+		"name": "engineering",
+		"description": "",
+		"email": "",
+		"sudo": null,
+		"ldap": {"groups": []},
+		"posix": [],
+		"radius": [],
+		"samba": null,
+		"properties": [{"name": "team", "value": "platform"}, {"name": "costCenter", "value": "1234"}],
+		"member_query": {
+			"type": "FilterQuery",
+			"combine": "and",
+			"filters": [{"field": "department", "operator": "eq", "value": "Engineering"}],
+			"include": [],
+			"exclude": ["5fa000000000000000000009"]
+		},
+		"notify": false,
+		"auto": false,
+		"timeouts": null
+	}`
+
+	state, diags := upgradeResourceState(t, r, "jumpcloud_usergroup", 1, rawState)
+	failOnDiagnostics(t, diags)
+
+	var upgraded UserGroupResourceModel
+	failOnDiagnostics(t, state.Get(ctx, &upgraded))
+
+	if len(upgraded.Properties) != 2 {
+		t.Errorf("Expected both properties in the upgraded set but got %v", upgraded.Properties)
+	}
+
+	// Empty lists and sets are no longer valid in version 2
+	if upgraded.PosixGroups != nil || upgraded.RadiusReplies != nil || upgraded.MemberQuery == nil || upgraded.MemberQuery.Include != nil || len(upgraded.MemberQuery.Exclude) != 1 {
+		t.Errorf("Expected empty lists and sets to be null but got %+v and member query %+v", upgraded, upgraded.MemberQuery)
+	}
+}
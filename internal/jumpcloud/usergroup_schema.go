@@ -1,17 +1,21 @@
 package jumpcloud
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
 	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/planmodifiers"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
 )
 
 var UserGroupSchema = tfsdk.Schema{
 	MarkdownDescription: "JumpCloud User Group",
 	Description:         "JumpCloud User Group",
-	Version:             0,
+	Version:             2,
 
 	Attributes: map[string]tfsdk.Attribute{
 		"id": {
@@ -76,6 +80,9 @@ var UserGroupSchema = tfsdk.Schema{
 				},
 			}),
 			Optional: true,
+			Validators: []tfsdk.AttributeValidator{
+				listvalidator.SizeAtLeast(1),
+			},
 		},
 		"radius": {
 			MarkdownDescription: "List of RADIUS Replies to associate with the user-group",
@@ -92,19 +99,29 @@ var UserGroupSchema = tfsdk.Schema{
 				},
 			}),
 			Optional: true,
+			Validators: []tfsdk.AttributeValidator{
+				listvalidator.SizeAtLeast(1),
+			},
 		},
 		"samba": {
 			MarkdownDescription: "Whether samba propogation is enabled for this user-group",
 			Type:                types.BoolType,
 			Optional:            true,
+			Computed:            true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{
+				resource.UseStateForUnknown(),
+			},
 		},
 		"properties": {
-			MarkdownDescription: "List of attribute properties to set on the user-group",
-			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+			MarkdownDescription: "List of custom attributes to set on the user-group. Properties added on JumpCloud show up as drift, values that are not strings there are shown JSON encoded and sent decoded from JSON, so they keep their type.",
+			Attributes: tfsdk.SetNestedAttributes(map[string]tfsdk.Attribute{
 				"name": {
-					MarkdownDescription: "The property name",
+					MarkdownDescription: "The property name, unique within the user-group. The names of the built-in attributes (`sudo`, `ldapGroups`, `posixGroups`, `radius` and `sambaEnabled`) cannot be used.",
 					Type:                types.StringType,
 					Required:            true,
+					Validators: []tfsdk.AttributeValidator{
+						stringvalidator.NoneOf(apiclient.UserGroupReservedAttributes...),
+					},
 				},
 				"value": {
 					MarkdownDescription: "The property value",
@@ -113,6 +130,9 @@ var UserGroupSchema = tfsdk.Schema{
 				},
 			}),
 			Optional: true,
+			Validators: []tfsdk.AttributeValidator{
+				setvalidator.SizeAtLeast(1),
+			},
 		},
 		"description": {
 			MarkdownDescription: "Description for the User Group",
@@ -136,19 +156,50 @@ var UserGroupSchema = tfsdk.Schema{
 				},
 			},
 		},
-		"member_queries": {
-			MarkdownDescription: "Query using a sequence of field filters.",
-			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-				"query": {
-					Required: true,
-					Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
+		"member_query": {
+			MarkdownDescription: "Users matching the query are suggested as members of the user-group, or added automatically when `auto` is set",
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"type": {
+					MarkdownDescription: "`FilterQuery` or `SearchQuery`, defaults to `FilterQuery`. Only a `SearchQuery` can combine its filters with `or`.",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: []tfsdk.AttributePlanModifier{
+						planmodifiers.StringConfigDefaultModifier{
+							Default: apiclient.MEMBER_QUERY_TYPE_FILTER,
+						},
+					},
+					Validators: []tfsdk.AttributeValidator{
+						stringvalidator.OneOf(apiclient.MEMBER_QUERY_TYPE_FILTER, apiclient.MEMBER_QUERY_TYPE_SEARCH),
+					},
+				},
+				"combine": {
+					MarkdownDescription: "Whether a user has to match all (`and`) or any (`or`) of the filters, defaults to `and`",
+					Type:                types.StringType,
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: []tfsdk.AttributePlanModifier{
+						planmodifiers.StringConfigDefaultModifier{
+							Default: apiclient.SEARCH_FILTER_AND,
+						},
+					},
+					Validators: []tfsdk.AttributeValidator{
+						stringvalidator.OneOf(apiclient.SEARCH_FILTER_AND, apiclient.SEARCH_FILTER_OR),
+					},
+				},
+				"filters": {
+					MarkdownDescription: "Filters a user is matched against",
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
 						"field": {
-							MarkdownDescription: "The name of the field to query",
+							MarkdownDescription: "The name of the user field to query, eg `department` or `addresses.country`",
 							Type:                types.StringType,
 							Required:            true,
+							Validators: []tfsdk.AttributeValidator{
+								stringvalidator.OneOf(apiclient.UserQueryFields...),
+							},
 						},
 						"operator": {
-							MarkdownDescription: "The operator to use for the query",
+							MarkdownDescription: "The operator to use for the query. `between` and `search` are only supported by a `FilterQuery`, `in` takes values separated by `|`.",
 							Type:                types.StringType,
 							Required:            true,
 							Validators: []tfsdk.AttributeValidator{
@@ -161,6 +212,27 @@ var UserGroupSchema = tfsdk.Schema{
 							Required:            true,
 						},
 					}),
+					Required: true,
+				},
+				"include": {
+					MarkdownDescription: "IDs of users that are members even though they do not match the query",
+					Type: types.SetType{
+						ElemType: types.StringType,
+					},
+					Optional: true,
+					Validators: []tfsdk.AttributeValidator{
+						setvalidator.SizeAtLeast(1),
+					},
+				},
+				"exclude": {
+					MarkdownDescription: "IDs of users that are never members even though they match the query",
+					Type: types.SetType{
+						ElemType: types.StringType,
+					},
+					Optional: true,
+					Validators: []tfsdk.AttributeValidator{
+						setvalidator.SizeAtLeast(1),
+					},
 				},
 			}),
 			Optional: true,
@@ -177,5 +249,6 @@ var UserGroupSchema = tfsdk.Schema{
 			Optional:            true,
 			Computed:            true,
 		},
+		"timeouts": timeouts.Attribute(),
 	},
 }
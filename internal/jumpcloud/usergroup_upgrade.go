@@ -0,0 +1,408 @@
+package jumpcloud
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/timeouts"
+)
+
+// userGroupSchemaV0 is the shape of UserGroupSchema at version 0, only the attribute
+// types matter to read saved state, so descriptions, validators and defaults are left out
+var userGroupSchemaV0 = tfsdk.Schema{
+	Version: 0,
+
+	Attributes: map[string]tfsdk.Attribute{
+		"id": {
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"name": {
+			Type:     types.StringType,
+			Required: true,
+		},
+		"sudo": {
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"enabled": {
+					Type:     types.BoolType,
+					Required: true,
+				},
+				"passwordless": {
+					Type:     types.BoolType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"ldap": {
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"groups": {
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+						"name": {
+							Type:     types.StringType,
+							Required: true,
+						},
+					}),
+					Optional: true,
+					Computed: true,
+				},
+			}),
+			Optional: true,
+			Computed: true,
+		},
+		"posix": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"id": {
+					Type:     types.Int64Type,
+					Required: true,
+				},
+				"name": {
+					Type:     types.StringType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"radius": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Required: true,
+				},
+				"value": {
+					Type:     types.StringType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"samba": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"properties": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Required: true,
+				},
+				"value": {
+					Type:     types.StringType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"description": {
+			Type:     types.StringType,
+			Optional: true,
+			Computed: true,
+		},
+		"email": {
+			Type:     types.StringType,
+			Optional: true,
+			Computed: true,
+		},
+		"member_queries": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"query": {
+					Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
+						"field": {
+							Type:     types.StringType,
+							Required: true,
+						},
+						"operator": {
+							Type:     types.StringType,
+							Required: true,
+						},
+						"value": {
+							Type:     types.StringType,
+							Required: true,
+						},
+					}),
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"notify": {
+			Type:     types.BoolType,
+			Optional: true,
+			Computed: true,
+		},
+		"auto": {
+			Type:     types.BoolType,
+			Optional: true,
+			Computed: true,
+		},
+		"timeouts": timeouts.Attribute(),
+	},
+}
+
+type userGroupResourceModelV0 struct {
+	Id                      types.String           `tfsdk:"id"`
+	Name                    types.String           `tfsdk:"name"`
+	Sudo                    *SudoConfigModel       `tfsdk:"sudo"`
+	Ldap                    types.Object           `tfsdk:"ldap"`
+	PosixGroups             []PosixGroupModel      `tfsdk:"posix"`
+	RadiusReplies           []KVItemModel          `tfsdk:"radius"`
+	Samba                   types.Bool             `tfsdk:"samba"`
+	Properties              []KVItemModel          `tfsdk:"properties"`
+	Description             types.String           `tfsdk:"description"`
+	Email                   types.String           `tfsdk:"email"`
+	MemberQueries           []memberQueriesModelV0 `tfsdk:"member_queries"`
+	MemberSuggestionsNotify types.Bool             `tfsdk:"notify"`
+	MembershipAutomated     types.Bool             `tfsdk:"auto"`
+	Timeouts                types.Object           `tfsdk:"timeouts"`
+}
+
+type memberQueriesModelV0 struct {
+	Query map[string]QueryFilterModel `tfsdk:"query"`
+}
+
+// upgradeUserGroupStateV0 moves the filters of every member_queries entry into the single
+// member_query of version 1. Version 0 always sent a FilterQuery requiring all filters, the
+// filters of each entry are kept in the order of their keys.
+func upgradeUserGroupStateV0(ctx context.Context, prior *userGroupResourceModelV0) (*UserGroupResourceModel, diag.Diagnostics) {
+	upgraded := &UserGroupResourceModel{
+		Id:                      prior.Id,
+		Name:                    prior.Name,
+		Sudo:                    prior.Sudo,
+		Ldap:                    prior.Ldap,
+		PosixGroups:             prior.PosixGroups,
+		RadiusReplies:           prior.RadiusReplies,
+		Samba:                   prior.Samba,
+		Properties:              prior.Properties,
+		Description:             prior.Description,
+		Email:                   prior.Email,
+		MemberSuggestionsNotify: prior.MemberSuggestionsNotify,
+		MembershipAutomated:     prior.MembershipAutomated,
+		Timeouts:                prior.Timeouts,
+	}
+
+	var filters []QueryFilterModel
+	for _, memberQuery := range prior.MemberQueries {
+		keys := make([]string, 0, len(memberQuery.Query))
+		for key := range memberQuery.Query {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			filters = append(filters, memberQuery.Query[key])
+		}
+	}
+
+	if len(filters) > 0 {
+		upgraded.MemberQuery = &UserGroupMemberQueryModel{
+			Type:    types.StringValue(apiclient.MEMBER_QUERY_TYPE_FILTER),
+			Combine: types.StringValue(apiclient.SEARCH_FILTER_AND),
+			Filters: filters,
+		}
+	}
+
+	return upgraded, nil
+}
+
+// userGroupSchemaV1 is the shape of UserGroupSchema at version 1, where properties were
+// still a list
+var userGroupSchemaV1 = tfsdk.Schema{
+	Version: 1,
+
+	Attributes: map[string]tfsdk.Attribute{
+		"id": {
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"name": {
+			Type:     types.StringType,
+			Required: true,
+		},
+		"sudo": {
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"enabled": {
+					Type:     types.BoolType,
+					Required: true,
+				},
+				"passwordless": {
+					Type:     types.BoolType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"ldap": {
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"groups": {
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+						"name": {
+							Type:     types.StringType,
+							Required: true,
+						},
+					}),
+					Optional: true,
+					Computed: true,
+				},
+			}),
+			Optional: true,
+			Computed: true,
+		},
+		"posix": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"id": {
+					Type:     types.Int64Type,
+					Required: true,
+				},
+				"name": {
+					Type:     types.StringType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"radius": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Required: true,
+				},
+				"value": {
+					Type:     types.StringType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"samba": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"properties": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Type:     types.StringType,
+					Required: true,
+				},
+				"value": {
+					Type:     types.StringType,
+					Required: true,
+				},
+			}),
+			Optional: true,
+		},
+		"description": {
+			Type:     types.StringType,
+			Optional: true,
+			Computed: true,
+		},
+		"email": {
+			Type:     types.StringType,
+			Optional: true,
+			Computed: true,
+		},
+		"member_query": {
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"type": {
+					Type:     types.StringType,
+					Optional: true,
+					Computed: true,
+				},
+				"combine": {
+					Type:     types.StringType,
+					Optional: true,
+					Computed: true,
+				},
+				"filters": {
+					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+						"field": {
+							Type:     types.StringType,
+							Required: true,
+						},
+						"operator": {
+							Type:     types.StringType,
+							Required: true,
+						},
+						"value": {
+							Type:     types.StringType,
+							Required: true,
+						},
+					}),
+					Required: true,
+				},
+				"include": {
+					Type: types.SetType{
+						ElemType: types.StringType,
+					},
+					Optional: true,
+				},
+				"exclude": {
+					Type: types.SetType{
+						ElemType: types.StringType,
+					},
+					Optional: true,
+				},
+			}),
+			Optional: true,
+		},
+		"notify": {
+			Type:     types.BoolType,
+			Optional: true,
+			Computed: true,
+		},
+		"auto": {
+			Type:     types.BoolType,
+			Optional: true,
+			Computed: true,
+		},
+		"timeouts": timeouts.Attribute(),
+	},
+}
+
+// upgradeUserGroupStateV1 upgrades to version 2, which stores properties as a set and no
+// longer accepts empty lists and sets. The model is the same, empty ones become null.
+func upgradeUserGroupStateV1(ctx context.Context, prior *UserGroupResourceModel) (*UserGroupResourceModel, diag.Diagnostics) {
+	upgraded := *prior
+
+	if len(upgraded.PosixGroups) == 0 {
+		upgraded.PosixGroups = nil
+	}
+
+	if len(upgraded.RadiusReplies) == 0 {
+		upgraded.RadiusReplies = nil
+	}
+
+	if len(upgraded.Properties) == 0 {
+		upgraded.Properties = nil
+	}
+
+	if upgraded.MemberQuery != nil {
+		memberQuery := *upgraded.MemberQuery
+
+		if len(memberQuery.Include) == 0 {
+			memberQuery.Include = nil
+		}
+
+		if len(memberQuery.Exclude) == 0 {
+			memberQuery.Exclude = nil
+		}
+
+		upgraded.MemberQuery = &memberQuery
+	}
+
+	return &upgraded, nil
+}
+
+// upgradeUserGroupStateV0ToV2 chains the upgrades of version 0 and 1
+func upgradeUserGroupStateV0ToV2(ctx context.Context, prior *userGroupResourceModelV0) (*UserGroupResourceModel, diag.Diagnostics) {
+	upgraded, diags := upgradeUserGroupStateV0(ctx, prior)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return upgradeUserGroupStateV1(ctx, upgraded)
+}
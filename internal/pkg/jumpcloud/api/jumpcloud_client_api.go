@@ -12,25 +12,37 @@ const API_CONTENT_TYPE = "application/json"
 
 type JumpCloudClientApiV2 struct {
 	Client *jcapiv2.APIClient
-	Auth   context.Context
+	ApiKey string
 }
 
 type JumpCloudClientApiV1 struct {
 	Client *jcapiv1.APIClient
-	Auth   context.Context
+	ApiKey string
 }
 
 func init() {
 }
 
-func (api JumpCloudClientApiV2) V2(client *jcapiv2.APIClient, auth context.Context) JumpCloudClientApiV2 {
+func (api JumpCloudClientApiV2) V2(client *jcapiv2.APIClient, apiKey string) JumpCloudClientApiV2 {
 	api.Client = client
-	api.Auth = auth
+	api.ApiKey = apiKey
 	return api
 }
 
-func (api JumpCloudClientApiV1) V1(client *jcapiv1.APIClient, auth context.Context) JumpCloudClientApiV1 {
+func (api JumpCloudClientApiV1) V1(client *jcapiv1.APIClient, apiKey string) JumpCloudClientApiV1 {
 	api.Client = client
-	api.Auth = auth
+	api.ApiKey = apiKey
 	return api
 }
+
+// Auth returns the context to pass to a jcapi-go call, it carries the API key and
+// is cancelled together with ctx
+func (api JumpCloudClientApiV2) Auth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, jcapiv2.ContextAPIKey, jcapiv2.APIKey{Key: api.ApiKey})
+}
+
+// Auth returns the context to pass to a jcapi-go call, it carries the API key and
+// is cancelled together with ctx
+func (api JumpCloudClientApiV1) Auth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, jcapiv1.ContextAPIKey, jcapiv1.APIKey{Key: api.ApiKey})
+}
@@ -0,0 +1,57 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	activeDirectoryApiVersion  = "v2"
+	activeDirectoryApiEndpoint = "activedirectories"
+
+	// Which directory is the source of truth for users and passwords
+	AD_USE_CASE_UNSET        = "UNSET"
+	AD_USE_CASE_AD_AUTHORITY = "ADASAUTHORITY"
+	AD_USE_CASE_JC_AUTHORITY = "JCASAUTHORITY"
+	AD_USE_CASE_TWO_WAY_SYNC = "TWOWAYSYNC"
+
+	// Whether password authentication is delegated to the domain controllers
+	AD_DELEGATION_ENABLED  = "ENABLED"
+	AD_DELEGATION_DISABLED = "DISABLED"
+)
+
+type (
+	// ActiveDirectory is an Active Directory integration. Settings left empty are
+	// not sent, so JumpCloud keeps its current or default value for them.
+	ActiveDirectory struct {
+		DelegationState string `json:"delegationState,omitempty"`
+		Domain          string `json:"domain,omitempty"`
+		GroupsEnabled   *bool  `json:"groupsEnabled,omitempty"`
+		Id              string `json:"id,omitempty"`
+		PrimaryAgent    string `json:"primaryAgent,omitempty"`
+		UseCase         string `json:"useCase,omitempty"`
+	}
+)
+
+func (c *Client) CreateActiveDirectory(ctx context.Context, create *ActiveDirectory) (ActiveDirectory, *http.Response, error) {
+	return Create(ctx, c, activeDirectoryApiVersion, activeDirectoryApiEndpoint, create)
+}
+
+func (c *Client) GetActiveDirectory(ctx context.Context, id string) (ActiveDirectory, *http.Response, error) {
+	return Get[ActiveDirectory](ctx, c, activeDirectoryApiVersion, fmt.Sprintf("%s/%s", activeDirectoryApiEndpoint, id), nil)
+}
+
+// UpdateActiveDirectory patches the settings of an integration, the domain itself
+// cannot be changed
+func (c *Client) UpdateActiveDirectory(ctx context.Context, update *ActiveDirectory) (ActiveDirectory, *http.Response, error) {
+	body := *update
+	body.Id = ""
+	body.Domain = ""
+
+	return Patch[ActiveDirectory](ctx, c, activeDirectoryApiVersion, fmt.Sprintf("%s/%s", activeDirectoryApiEndpoint, update.Id), &body)
+}
+
+func (c *Client) DeleteActiveDirectory(ctx context.Context, id string) (*http.Response, error) {
+	return Delete(ctx, c, activeDirectoryApiVersion, fmt.Sprintf("%s/%s", activeDirectoryApiEndpoint, id))
+}
@@ -8,24 +8,28 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type (
 	Client struct {
 		ApiKey          string
+		BaseUrl         string
+		OrgId           string
 		httpClient      *http.Client
 		ProviderVersion string
-		Context         context.Context
-	}
 
-	reusableReader struct {
-		io.Reader
-		readBuf *bytes.Buffer
-		backBuf *bytes.Buffer
+		maxRetries        int
+		retryMaxWait      time.Duration
+		requestsPerSecond int
+		recorderMode      string
+		recorderCassette  string
 	}
+
+	ClientOption func(*Client)
 )
 
 const (
@@ -34,68 +38,140 @@ const (
 	SUBSYSTEM_NAME         = "apiclient.Client"
 )
 
-func (c *Client) ReusableReader(r io.Reader) io.Reader {
-	readBuf := bytes.Buffer{}
-	readBuf.ReadFrom(r)
-	backBuf := bytes.Buffer{}
+func (c *Client) ReadBody(r io.Reader) string {
+	b, _ := io.ReadAll(r)
+	return string(b)
+}
 
-	return reusableReader{
-		io.TeeReader(&readBuf, &backBuf),
-		&readBuf,
-		&backBuf,
+// WithBaseUrl points the client at a different API root than JUMPCLOUD_API_BASE_URL,
+// eg the EU region (https://console.eu.jumpcloud.com/api) or a local stand-in server
+func WithBaseUrl(baseUrl string) ClientOption {
+	return func(c *Client) {
+		if baseUrl != "" {
+			c.BaseUrl = strings.TrimSuffix(baseUrl, "/")
+		}
 	}
 }
 
-func (r reusableReader) Read(p []byte) (int, error) {
-	n, err := r.Reader.Read(p)
-	if err == io.EOF {
-		r.Reset()
+// WithOrgId scopes every request to a single organization through the x-org-id
+// header, as required for multi-tenant (MSP) administrator API keys
+func WithOrgId(orgId string) ClientOption {
+	return func(c *Client) {
+		c.OrgId = orgId
 	}
-	return n, err
 }
 
-func (r reusableReader) Reset() {
-	io.Copy(r.readBuf, r.backBuf)
+// WithRetry sets how often a rate limited or failed request is retried and the
+// longest the client will wait between two attempts
+func WithRetry(maxRetries int, maxWait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryMaxWait = maxWait
+	}
 }
 
-func (c *Client) ReadBody(r io.Reader) string {
-	b, _ := io.ReadAll(r)
-	return string(b)
+// WithRateLimit caps the number of requests per second sent to the API across
+// everything sharing this client. A value of 0 disables the limiter.
+func WithRateLimit(requestsPerSecond int) ClientOption {
+	return func(c *Client) {
+		c.requestsPerSecond = requestsPerSecond
+	}
 }
 
-func New(ctx context.Context, apikey string, providerVersion string) Client {
-	client := &http.Client{}
+// WithRecorder records every request and response to the cassette file at path
+// (RECORDER_MODE_RECORD) or answers requests from it without using the network
+// (RECORDER_MODE_REPLAY). An empty mode leaves recording off.
+func WithRecorder(mode string, path string) ClientOption {
+	return func(c *Client) {
+		c.recorderMode = mode
+		c.recorderCassette = path
+	}
+}
 
+func New(ctx context.Context, apikey string, providerVersion string, options ...ClientOption) Client {
 	tflog.Info(ctx, fmt.Sprintf("Initializing %s Logging Subsystem", SUBSYSTEM_NAME))
 
-	return Client{
+	c := Client{
 		ApiKey:          apikey,
-		httpClient:      client,
+		BaseUrl:         JUMPCLOUD_API_BASE_URL,
 		ProviderVersion: providerVersion,
-		Context:         tflog.NewSubsystem(ctx, SUBSYSTEM_NAME, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_JUMPCLOUD_CLIENT")),
+		maxRetries:      DEFAULT_MAX_RETRIES,
+		retryMaxWait:    DEFAULT_RETRY_MAX_WAIT,
+
+		requestsPerSecond: DEFAULT_REQUESTS_PER_SECOND,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+
+	if c.recorderMode != "" {
+		recorder, err := newRecorderTransport(transport, c.recorderMode, c.recorderCassette)
+		if err != nil {
+			tflog.SubsystemError(c.logContext(ctx), SUBSYSTEM_NAME, "Unable to set up the request recorder", map[string]interface{}{
+				"err": err.Error(),
+			})
+			transport = errorTransport{err: err}
+		} else {
+			transport = recorder
+		}
 	}
+
+	// Replayed responses do not count against JumpCloud's limits
+	if c.requestsPerSecond > 0 && c.recorderMode != RECORDER_MODE_REPLAY {
+		transport = &rateLimitTransport{
+			next:    transport,
+			limiter: newRateLimiter(float64(c.requestsPerSecond), c.requestsPerSecond),
+		}
+	}
+
+	c.httpClient = &http.Client{
+		Transport: &loggingTransport{
+			next:       newRetryTransport(transport, c.maxRetries, c.retryMaxWait),
+			logContext: c.logContext,
+		},
+	}
+
+	return c
 }
 
-func (c *Client) getPayloadAsString(payload interface{}) string {
-	var buf *bytes.Buffer = &bytes.Buffer{}
-	err := json.NewEncoder(buf).Encode(payload)
-	if err != nil {
-		tflog.SubsystemError(c.Context, SUBSYSTEM_NAME, spew.Sprintf("Error while encoding payload as string: %s", err), map[string]interface{}{
-			"method": "getPayloadAsString",
-		})
-		return "<nil>"
+// logContext sets up the client logging subsystem on ctx, the context of the
+// resource operation a request is made for
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, SUBSYSTEM_NAME, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_JUMPCLOUD_CLIENT"))
+
+	// Keeps the logs of provider aliases for different organizations apart
+	if c.OrgId != "" {
+		ctx = tflog.SubsystemSetField(ctx, SUBSYSTEM_NAME, "org_id", c.OrgId)
 	}
 
-	return buf.String()
+	// Catches the key wherever it ends up, eg in the message of a transport error
+	if c.ApiKey != "" {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, SUBSYSTEM_NAME, c.ApiKey)
+	}
+
+	return ctx
+}
+
+// HTTPClient returns the http.Client used by this client, so that the generated
+// jcapi-go clients can share the same transport behaviour
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
 }
 
 func (c *Client) prepareRequest(
+	ctx context.Context,
 	method string,
 	apiVersion string, endpoint string,
 	postBody interface{},
 	headerParams map[string]string,
 	queryParams url.Values) (request *http.Request, err error) {
-	request_url := fmt.Sprintf("%s/%s/%s", JUMPCLOUD_API_BASE_URL, apiVersion, endpoint)
+	request_url := fmt.Sprintf("%s/%s/%s", c.BaseUrl, apiVersion, endpoint)
+	if apiVersion == "" {
+		request_url = fmt.Sprintf("%s/%s", c.BaseUrl, endpoint)
+	}
 
 	var body *bytes.Buffer
 
@@ -123,9 +199,9 @@ func (c *Client) prepareRequest(
 	url.RawQuery = query.Encode()
 
 	if body != nil {
-		request, err = http.NewRequest(method, url.String(), body)
+		request, err = http.NewRequestWithContext(ctx, method, url.String(), body)
 	} else {
-		request, err = http.NewRequest(method, url.String(), nil)
+		request, err = http.NewRequestWithContext(ctx, method, url.String(), nil)
 	}
 
 	if err != nil {
@@ -145,5 +221,44 @@ func (c *Client) prepareRequest(
 	request.Header.Add("Accept", CONTENT_TYPE)
 	request.Header.Add("Content-Type", CONTENT_TYPE)
 
+	if c.OrgId != "" {
+		request.Header.Set("x-org-id", c.OrgId)
+	}
+
 	return request, nil
 }
+
+func (c *Client) do(request *http.Request, payload interface{}) (*http.Response, error) {
+	response, err := c.httpClient.Do(request)
+	if err != nil || response == nil {
+		return response, err
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return response, err
+	}
+
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	if response.StatusCode >= 300 {
+		return response, NewAPIError(response, body)
+	}
+
+	if payload == nil || len(body) == 0 {
+		return response, nil
+	}
+
+	if err = json.Unmarshal(body, payload); err != nil {
+		tflog.SubsystemError(request.Context(), SUBSYSTEM_NAME, "Error while Unmarshalling Response", map[string]interface{}{
+			"method":   request.Method,
+			"url":      request.URL.String(),
+			"response": redactBody(body),
+			"err":      err,
+		})
+	}
+
+	return response, err
+}
@@ -0,0 +1,68 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The functions below are the building blocks of every object client. They build
+// the request, log it, turn failed responses into an *APIError and unmarshal the
+// response into T, so a new object type only has to declare its struct, API version
+// and endpoint. Go does not allow type parameters on methods, so they take the
+// client as their first argument.
+
+// call sends a single request to apiVersion/endpoint, bound to ctx, and unmarshals the response into payload when it is not nil
+func (c *Client) call(ctx context.Context, method string, apiVersion string, endpoint string, body interface{}, query url.Values, payload interface{}) (*http.Response, error) {
+	ctx = c.logContext(ctx)
+
+	tflog.SubsystemInfo(ctx, SUBSYSTEM_NAME, "Calling JumpCloud API", map[string]interface{}{
+		"method":     method,
+		"apiVersion": apiVersion,
+		"endpoint":   endpoint,
+	})
+
+	request, err := c.prepareRequest(ctx, method, apiVersion, endpoint, body, nil, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(request, payload)
+}
+
+// Get fetches a single object
+func Get[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, query url.Values) (payload T, response *http.Response, err error) {
+	response, err = c.call(ctx, http.MethodGet, apiVersion, endpoint, nil, query, &payload)
+	return payload, response, err
+}
+
+// List fetches a single page of a collection that responds with a JSON array
+func List[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, query url.Values) (payload []T, response *http.Response, err error) {
+	response, err = c.call(ctx, http.MethodGet, apiVersion, endpoint, nil, query, &payload)
+	return payload, response, err
+}
+
+// Create POSTs a new object to a collection and returns it as created by JumpCloud
+func Create[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, create *T) (payload T, response *http.Response, err error) {
+	response, err = c.call(ctx, http.MethodPost, apiVersion, endpoint, create, nil, &payload)
+	return payload, response, err
+}
+
+// Update PUTs the full object, replacing what JumpCloud has stored
+func Update[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, update *T) (payload T, response *http.Response, err error) {
+	response, err = c.call(ctx, http.MethodPut, apiVersion, endpoint, update, nil, &payload)
+	return payload, response, err
+}
+
+// Patch sends a partial object, only the fields present in patch are changed
+func Patch[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, patch interface{}) (payload T, response *http.Response, err error) {
+	response, err = c.call(ctx, http.MethodPatch, apiVersion, endpoint, patch, nil, &payload)
+	return payload, response, err
+}
+
+// Delete removes an object, any response body is ignored
+func Delete(ctx context.Context, c *Client, apiVersion string, endpoint string) (*http.Response, error) {
+	return c.call(ctx, http.MethodDelete, apiVersion, endpoint, nil, nil, nil)
+}
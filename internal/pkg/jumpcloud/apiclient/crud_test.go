@@ -0,0 +1,108 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testObject struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func TestCrudHelpers(t *testing.T) {
+	var lastMethod, lastPath, lastBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lastMethod, lastPath, lastBody = r.Method, r.URL.Path, string(body)
+
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path == "/api/v2/objects" {
+				json.NewEncoder(w).Encode([]testObject{{Id: "1", Name: "one"}, {Id: "2", Name: "two"}})
+				return
+			}
+			json.NewEncoder(w).Encode(testObject{Id: "1", Name: "one"})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			var object testObject
+			json.Unmarshal(body, &object)
+			if object.Id == "" {
+				object.Id = "1"
+			}
+			json.NewEncoder(w).Encode(object)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := New(ctx, "key", "test", WithBaseUrl(server.URL+"/api"))
+
+	list, _, err := List[testObject](ctx, &c, "v2", "objects", nil)
+	if err != nil || len(list) != 2 || list[1].Name != "two" {
+		t.Errorf("Unexpected List result %v (%v)", list, err)
+	}
+
+	got, _, err := Get[testObject](ctx, &c, "v2", "objects/1", nil)
+	if err != nil || got.Name != "one" || lastPath != "/api/v2/objects/1" {
+		t.Errorf("Unexpected Get result %v from %s (%v)", got, lastPath, err)
+	}
+
+	created, _, err := Create(ctx, &c, "v2", "objects", &testObject{Name: "new"})
+	if err != nil || created.Id != "1" || lastMethod != http.MethodPost {
+		t.Errorf("Unexpected Create result %v with %s (%v)", created, lastMethod, err)
+	}
+
+	updated, _, err := Update(ctx, &c, "v2", "objects/1", &testObject{Id: "1", Name: "renamed"})
+	if err != nil || updated.Name != "renamed" || lastMethod != http.MethodPut {
+		t.Errorf("Unexpected Update result %v with %s (%v)", updated, lastMethod, err)
+	}
+
+	patched, _, err := Patch[testObject](ctx, &c, "v2", "objects/1", map[string]string{"name": "patched"})
+	if err != nil || patched.Name != "patched" || lastMethod != http.MethodPatch || lastBody != "{\"name\":\"patched\"}\n" {
+		t.Errorf("Unexpected Patch result %v with %s %q (%v)", patched, lastMethod, lastBody, err)
+	}
+
+	if _, err := Delete(ctx, &c, "v2", "objects/1"); err != nil || lastMethod != http.MethodDelete {
+		t.Errorf("Unexpected Delete result with %s (%v)", lastMethod, err)
+	}
+
+	// v1 objects live directly under the API root
+	if _, _, err := Get[testObject](ctx, &c, "", "systemusers/1", nil); err != nil || lastPath != "/api/systemusers/1" {
+		t.Errorf("Expected a v1 request to /api/systemusers/1 but got %s (%v)", lastPath, err)
+	}
+}
+
+func TestCallIsCancelledWithContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := New(context.Background(), "key", "test", WithBaseUrl(server.URL+"/api"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if _, _, err := Get[testObject](ctx, &c, "v2", "objects/1", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to be cancelled with its context but got %v", err)
+	}
+
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Cancelling the context should abort the request, it took %s", elapsed)
+	}
+}
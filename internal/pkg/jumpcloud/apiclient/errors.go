@@ -0,0 +1,112 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Longest raw response body kept on an APIError when JumpCloud did not send a message
+	apiErrorBodyLimit = 512
+)
+
+// APIError is returned for every response from JumpCloud with a status of 300 or above
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	RequestId  string
+	Method     string
+	URL        string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s returned %s", e.Method, e.URL, e.Status)
+
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+
+	if e.RequestId != "" {
+		fmt.Fprintf(&b, " (request id: %s)", e.RequestId)
+	}
+
+	return b.String()
+}
+
+// NewAPIError builds an APIError from a failed response and its already read body
+func NewAPIError(response *http.Response, body []byte) *APIError {
+	apiError := &APIError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		RequestId:  response.Header.Get("X-Request-Id"),
+		Body:       string(body),
+	}
+
+	if apiError.Status == "" {
+		apiError.Status = fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	if response.Request != nil {
+		apiError.Method = response.Request.Method
+		apiError.URL = response.Request.URL.Redacted()
+	}
+
+	apiError.Message = parseErrorMessage(body)
+
+	return apiError
+}
+
+// parseErrorMessage extracts the human readable message from a JumpCloud error body.
+// v1 and v2 endpoints use either "message" or "error" for it.
+func parseErrorMessage(body []byte) string {
+	var payload struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if payload.Message != "" {
+			return payload.Message
+		}
+		if payload.Error != "" {
+			return payload.Error
+		}
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > apiErrorBodyLimit {
+		message = message[:apiErrorBodyLimit] + "..."
+	}
+
+	return message
+}
+
+// ErrNotFound is wrapped by the errors of lookups that matched nothing, eg by name
+var ErrNotFound = errors.New("not found")
+
+func hasStatus(err error, statusCode int) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == statusCode
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response or wraps ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError for a 409 response
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is an APIError for a 429 response
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
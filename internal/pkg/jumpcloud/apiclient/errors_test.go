@@ -0,0 +1,61 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorFromClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := New(context.Background(), "key", "test", WithBaseUrl(server.URL))
+
+	_, _, err := client.GetUserDetails(context.Background(), "63a1b2c3d4e5f6a7b8c9d0e1")
+	if !IsNotFound(err) {
+		t.Fatalf("Expected a not found error but got %v", err)
+	}
+
+	if IsConflict(err) || IsRateLimited(err) {
+		t.Errorf("Expected only IsNotFound to match %v", err)
+	}
+
+	expected := fmt.Sprintf("GET %s/systemusers/63a1b2c3d4e5f6a7b8c9d0e1 returned 404 Not Found: Not Found (request id: req-123)", server.URL)
+	if err.Error() != expected {
+		t.Errorf("Expected %q but got %q", expected, err.Error())
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"message": {body: `{"message":"Conflict: name already exists"}`, expected: "Conflict: name already exists"},
+		"error":   {body: `{"error":"Unauthorized"}`, expected: "Unauthorized"},
+		"text":    {body: " upstream connect error \n", expected: "upstream connect error"},
+		"empty":   {body: "", expected: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if message := parseErrorMessage([]byte(test.body)); message != test.expected {
+				t.Errorf("Expected %q but got %q", test.expected, message)
+			}
+		})
+	}
+}
+
+func TestIsNotFoundWithoutResponse(t *testing.T) {
+	// Lookups that match nothing are not found without a 404 response
+	if err := fmt.Errorf("no user group named %q: %w", "missing", ErrNotFound); !IsNotFound(err) || IsConflict(err) {
+		t.Errorf("Expected only IsNotFound to match %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	graphApiVersion = "v2"
+
+	GRAPH_OP_ADD    = "add"
+	GRAPH_OP_REMOVE = "remove"
+	GRAPH_OP_UPDATE = "update"
+)
+
+// GraphTypeEndpoints maps every graph object type to its v2 collection endpoint
+var GraphTypeEndpoints = map[string]string{
+	"active_directory": "activedirectories",
+	"application":      "applications",
+	"command":          "commands",
+	"g_suite":          "gsuites",
+	"ldap_server":      "ldapservers",
+	"office_365":       "office365s",
+	"policy":           "policies",
+	"policy_group":     "policygroups",
+	"radius_server":    "radiusservers",
+	"system":           "systems",
+	"system_group":     "systemgroups",
+	"user":             "users",
+	"user_group":       "usergroups",
+}
+
+// GraphAssociationTargets lists, for every graph object type, the types it can be
+// directly associated with
+var GraphAssociationTargets = map[string][]string{
+	"active_directory": {"user", "user_group"},
+	"application":      {"user", "user_group"},
+	"command":          {"system", "system_group"},
+	"g_suite":          {"user", "user_group"},
+	"ldap_server":      {"user", "user_group"},
+	"office_365":       {"user", "user_group"},
+	"policy":           {"system", "system_group"},
+	"policy_group":     {"system", "system_group"},
+	"radius_server":    {"user", "user_group"},
+	"system":           {"command", "policy", "policy_group", "user", "user_group"},
+	"system_group":     {"command", "policy", "policy_group", "user", "user_group"},
+	"user":             {"active_directory", "application", "g_suite", "ldap_server", "office_365", "radius_server", "system", "system_group"},
+	"user_group":       {"active_directory", "application", "g_suite", "ldap_server", "office_365", "radius_server", "system", "system_group"},
+}
+
+type (
+	GraphObject struct {
+		Id         string                 `json:"id"`
+		Type       string                 `json:"type"`
+		Attributes map[string]interface{} `json:"attributes,omitempty"`
+	}
+
+	GraphConnection struct {
+		To         GraphObject            `json:"to"`
+		Attributes map[string]interface{} `json:"attributes,omitempty"`
+	}
+
+	GraphOperation struct {
+		Op         string                 `json:"op"`
+		Type       string                 `json:"type"`
+		Id         string                 `json:"id"`
+		Attributes map[string]interface{} `json:"attributes,omitempty"`
+	}
+)
+
+// IsGraphAssociationAllowed reports whether fromType can be associated with toType
+func IsGraphAssociationAllowed(fromType string, toType string) bool {
+	for _, target := range GraphAssociationTargets[fromType] {
+		if target == toType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func graphEndpoint(objectType string) (string, error) {
+	endpoint, ok := GraphTypeEndpoints[objectType]
+	if !ok {
+		return "", fmt.Errorf("unsupported graph object type %q", objectType)
+	}
+
+	return endpoint, nil
+}
+
+// listGraph walks all pages of a v2 graph collection
+func (c *Client) listGraph(ctx context.Context, endpoint string, params url.Values) ([]GraphConnection, error) {
+	return ListAll[GraphConnection](ctx, c, graphApiVersion, endpoint, ListOptions{Query: params})
+}
+
+func (c *Client) modifyGraph(ctx context.Context, endpoint string, operation GraphOperation) (*http.Response, error) {
+	tflog.SubsystemInfo(c.logContext(ctx), SUBSYSTEM_NAME, "Modifying graph", map[string]interface{}{
+		"client":   "Graph",
+		"endpoint": endpoint,
+		"op":       operation.Op,
+		"type":     operation.Type,
+		"targetId": operation.Id,
+	})
+
+	return c.call(ctx, http.MethodPost, graphApiVersion, endpoint, operation, nil, nil)
+}
+
+// ListGraphMembers returns every member connection of a group, walking all pages
+// of the v2 {groupEndpoint}/{id}/members collection
+func (c *Client) ListGraphMembers(ctx context.Context, groupEndpoint string, groupId string) ([]GraphConnection, error) {
+	return c.listGraph(ctx, fmt.Sprintf("%s/%s/members", groupEndpoint, groupId), nil)
+}
+
+// ModifyGraphMember adds or removes a single member of a group
+func (c *Client) ModifyGraphMember(ctx context.Context, groupEndpoint string, groupId string, operation GraphOperation) (*http.Response, error) {
+	return c.modifyGraph(ctx, fmt.Sprintf("%s/%s/members", groupEndpoint, groupId), operation)
+}
+
+// ListAssociations returns every direct association of the given object with objects of toType
+func (c *Client) ListAssociations(ctx context.Context, fromType string, fromId string, toType string) ([]GraphConnection, error) {
+	endpoint, err := graphEndpoint(fromType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("targets", toType)
+
+	return c.listGraph(ctx, fmt.Sprintf("%s/%s/associations", endpoint, fromId), query)
+}
+
+// GetAssociation returns the association between two objects, or nil when they are not associated
+func (c *Client) GetAssociation(ctx context.Context, fromType string, fromId string, toType string, toId string) (*GraphConnection, error) {
+	connections, err := c.ListAssociations(ctx, fromType, fromId, toType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, connection := range connections {
+		if connection.To.Id == toId {
+			return &connection, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ModifyAssociation adds, updates or removes an association of the given object
+func (c *Client) ModifyAssociation(ctx context.Context, fromType string, fromId string, operation GraphOperation) (*http.Response, error) {
+	endpoint, err := graphEndpoint(fromType)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.modifyGraph(ctx, fmt.Sprintf("%s/%s/associations", endpoint, fromId), operation)
+}
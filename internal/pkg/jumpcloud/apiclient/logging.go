@@ -0,0 +1,179 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Replaces the value of sensitive headers and JSON fields in logs and cassettes
+	REDACTED = "REDACTED"
+
+	// Longest request or response body written to the logs, in bytes
+	MAX_LOGGED_BODY_SIZE = 4096
+)
+
+// Headers that are never logged or written to a cassette in the clear
+var sensitiveHeaders = []string{"x-api-key", "Authorization", "Cookie", "Set-Cookie"}
+
+// JSON fields whose values are never logged, matched case-insensitively and
+// ignoring _ and -, so both totp_secret and totpSecret are covered
+var sensitiveFields = map[string]bool{
+	"password":     true,
+	"newpassword":  true,
+	"oldpassword":  true,
+	"totpsecret":   true,
+	"mfasecret":    true,
+	"sharedsecret": true,
+	"radiussecret": true,
+	"secret":       true,
+	"apikey":       true,
+	"xapikey":      true,
+	"token":        true,
+	"accesstoken":  true,
+	"refreshtoken": true,
+	"clientsecret": true,
+	"privatekey":   true,
+}
+
+// loggingTransport logs every request to the JumpCloud API, including those made by
+// the jcapi-go clients. The method, URL, status and duration are logged at DEBUG,
+// headers and bodies only at TRACE, with sensitive values redacted and bodies truncated.
+type loggingTransport struct {
+	next       http.RoundTripper
+	logContext func(context.Context) context.Context
+}
+
+func (t *loggingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Requests from the jcapi-go clients arrive without the logging subsystem,
+	// the transports below log with the request context as well
+	ctx := t.logContext(request.Context())
+	request = request.WithContext(ctx)
+
+	tflog.SubsystemDebug(ctx, SUBSYSTEM_NAME, "Sending request to JumpCloud API", map[string]interface{}{
+		"method": request.Method,
+		"url":    request.URL.String(),
+	})
+
+	if body, err := readRequestBody(request); err == nil {
+		tflog.SubsystemTrace(ctx, SUBSYSTEM_NAME, "JumpCloud API request", map[string]interface{}{
+			"method":  request.Method,
+			"url":     request.URL.String(),
+			"headers": scrubHeaders(request.Header),
+			"body":    redactBody([]byte(body)),
+		})
+	}
+
+	started := time.Now()
+	response, err := t.next.RoundTrip(request)
+
+	fields := map[string]interface{}{
+		"method":   request.Method,
+		"url":      request.URL.String(),
+		"duration": time.Since(started).String(),
+	}
+
+	if err != nil {
+		fields["err"] = err.Error()
+		tflog.SubsystemDebug(ctx, SUBSYSTEM_NAME, "Request to JumpCloud API failed", fields)
+
+		return response, err
+	}
+
+	fields["status"] = response.Status
+	tflog.SubsystemDebug(ctx, SUBSYSTEM_NAME, "Received response from JumpCloud API", fields)
+
+	body, readErr := io.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	if readErr != nil {
+		return response, readErr
+	}
+
+	tflog.SubsystemTrace(ctx, SUBSYSTEM_NAME, "JumpCloud API response", map[string]interface{}{
+		"method":  request.Method,
+		"url":     request.URL.String(),
+		"status":  response.Status,
+		"headers": scrubHeaders(response.Header),
+		"body":    redactBody(body),
+	})
+
+	return response, nil
+}
+
+func scrubHeaders(headers http.Header) http.Header {
+	scrubbed := headers.Clone()
+
+	for _, name := range sensitiveHeaders {
+		if scrubbed.Get(name) != "" {
+			scrubbed.Set(name, REDACTED)
+		}
+	}
+
+	return scrubbed
+}
+
+// redactBody prepares a request or response body for the logs. Sensitive fields of
+// JSON bodies are redacted at any depth and the result is cut at MAX_LOGGED_BODY_SIZE.
+func redactBody(body []byte) string {
+	return truncate(redactJSON(body), MAX_LOGGED_BODY_SIZE)
+}
+
+// redactJSON redacts the sensitive fields of a JSON body at any depth, anything else
+// is returned as it is
+func redactJSON(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		if redacted, err := json.Marshal(redactValue(value)); err == nil {
+			body = redacted
+		}
+	}
+
+	return string(body)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				v[key] = REDACTED
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+func isSensitiveField(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+
+	return sensitiveFields[normalized]
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	return fmt.Sprintf("%s... (%d more bytes)", s[:max], len(s)-max)
+}
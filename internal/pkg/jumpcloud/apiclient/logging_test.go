@@ -0,0 +1,81 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := map[string]string{
+		`{"username":"jdoe","password":"hunter2"}`:                  `{"password":"REDACTED","username":"jdoe"}`,
+		`{"user":{"totp_secret":"ABC","mfa":{"totpSecret":"DEF"}}}`: `{"user":{"mfa":{"totpSecret":"REDACTED"},"totp_secret":"REDACTED"}}`,
+		`[{"name":"radius","sharedSecret":"s3cr3t"}]`:               `[{"name":"radius","sharedSecret":"REDACTED"}]`,
+		`{"password_never_expires":true,"passwordless_sudo":false}`: `{"password_never_expires":true,"passwordless_sudo":false}`,
+		`not json, left as it is`:                                   `not json, left as it is`,
+		``:                                                          ``,
+	}
+
+	for body, expected := range cases {
+		if got := redactBody([]byte(body)); got != expected {
+			t.Errorf("Expected %s to be logged as %s but got %s", body, expected, got)
+		}
+	}
+
+	long := strings.Repeat("a", MAX_LOGGED_BODY_SIZE+10)
+	if got := redactBody([]byte(long)); got != strings.Repeat("a", MAX_LOGGED_BODY_SIZE)+"... (10 more bytes)" {
+		t.Errorf("Expected the body to be truncated but got %d bytes", len(got))
+	}
+}
+
+func TestLoggingTransportRedactsSecrets(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_JUMPCLOUD_CLIENT", "TRACE")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=c00k13")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := New(ctx, "s3cr3t-api-key", "test", WithBaseUrl(server.URL), WithRetry(0, 0))
+
+	if _, _, err := c.CreateUser(ctx, &User{Username: "jdoe", Password: "hunter2"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Unable to decode the logs: %s", err)
+	}
+
+	logged := output.String()
+	for _, secret := range []string{"s3cr3t-api-key", "hunter2", "c00k13"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected %q to be redacted from the logs", secret)
+		}
+	}
+
+	bodies := 0
+	for _, entry := range entries {
+		if body, ok := entry["body"].(string); ok && strings.Contains(body, `"password":"REDACTED"`) {
+			if entry["@level"] != "trace" {
+				t.Errorf("Expected bodies to be logged at TRACE but got %v", entry["@level"])
+			}
+			bodies++
+		}
+	}
+
+	if bodies != 2 {
+		t.Errorf("Expected the redacted request and response bodies in the logs but got %d in\n%s", bodies, logged)
+	}
+}
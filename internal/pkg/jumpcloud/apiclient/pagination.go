@@ -0,0 +1,128 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Largest page size JumpCloud accepts on its collection endpoints
+	DEFAULT_PAGE_LIMIT = 100
+)
+
+// ErrStopIteration can be returned from a ForEach callback to stop walking the
+// collection early without ForEach returning an error
+var ErrStopIteration = errors.New("stop iteration")
+
+type (
+	// ListOptions narrow and order a collection listing
+	ListOptions struct {
+		// Filters of the form field:operator:value, eg name:eq:admins for v2 or
+		// username:$eq:jdoe for v1. Multiple filters must all match.
+		Filter []string
+
+		// Fields to sort by, prefixed with - for descending order
+		Sort []string
+
+		// Page size, defaults to DEFAULT_PAGE_LIMIT
+		Limit int
+
+		// Extra query parameters sent with every page, eg targets for graph listings
+		Query url.Values
+	}
+
+	// v1 collections wrap every page in an object that also carries the total count
+	v1Page[T any] struct {
+		TotalCount int `json:"totalCount"`
+		Results    []T `json:"results"`
+	}
+)
+
+func (o ListOptions) pageLimit() int {
+	if o.Limit <= 0 || o.Limit > DEFAULT_PAGE_LIMIT {
+		return DEFAULT_PAGE_LIMIT
+	}
+
+	return o.Limit
+}
+
+func (o ListOptions) query(apiVersion string, limit int, skip int) url.Values {
+	query := url.Values{}
+	for k, v := range o.Query {
+		query[k] = append([]string{}, v...)
+	}
+
+	for _, filter := range o.Filter {
+		query.Add("filter", filter)
+	}
+
+	// v1 separates sort fields with spaces, v2 with commas
+	if len(o.Sort) > 0 {
+		separator := ","
+		if apiVersion == "" {
+			separator = " "
+		}
+		query.Set("sort", strings.Join(o.Sort, separator))
+	}
+
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("skip", strconv.Itoa(skip))
+
+	return query
+}
+
+// ForEach walks every page of a collection and calls fn for each object, so only one
+// page is held in memory at a time. v2 collections (apiVersion "v2") respond with a
+// plain array and end with a short page. v1 collections (apiVersion "") respond with
+// totalCount and results. Returning ErrStopIteration from fn stops early; any other
+// error stops and is returned.
+func ForEach[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, options ListOptions, fn func(T) error) error {
+	limit := options.pageLimit()
+
+	for skip := 0; ; skip += limit {
+		var page []T
+		total := -1
+
+		if apiVersion == "" {
+			wrapped, _, err := Get[v1Page[T]](ctx, c, apiVersion, endpoint, options.query(apiVersion, limit, skip))
+			if err != nil {
+				return err
+			}
+			page, total = wrapped.Results, wrapped.TotalCount
+		} else {
+			var err error
+			if page, _, err = List[T](ctx, c, apiVersion, endpoint, options.query(apiVersion, limit, skip)); err != nil {
+				return err
+			}
+		}
+
+		for _, object := range page {
+			if err := fn(object); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if len(page) < limit || (total >= 0 && skip+len(page) >= total) {
+			return nil
+		}
+	}
+}
+
+// ListAll collects every object of a collection, see ForEach for walking large
+// collections without holding them in memory
+func ListAll[T any](ctx context.Context, c *Client, apiVersion string, endpoint string, options ListOptions) ([]T, error) {
+	objects := []T{}
+
+	err := ForEach(ctx, c, apiVersion, endpoint, options, func(object T) error {
+		objects = append(objects, object)
+		return nil
+	})
+
+	return objects, err
+}
@@ -0,0 +1,111 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// newPagingServer serves count objects from path, one page per skip and limit, wrapped
+// the way v1 collections are when v1 is set
+func newPagingServer(t *testing.T, path string, count int, v1 bool, queries *[]url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("Expected a request to %s but got %s", path, r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		*queries = append(*queries, query)
+
+		skip, _ := strconv.Atoi(query.Get("skip"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		page := []map[string]string{}
+		for i := skip; i < count && i < skip+limit; i++ {
+			page = append(page, map[string]string{"id": strconv.Itoa(i), "name": fmt.Sprintf("object-%02d", i), "username": fmt.Sprintf("user-%02d", i)})
+		}
+
+		if v1 {
+			json.NewEncoder(w).Encode(map[string]interface{}{"totalCount": count, "results": page})
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestPaginationV2(t *testing.T) {
+	var queries []url.Values
+
+	server := newPagingServer(t, "/api/v2/usergroups", 25, false, &queries)
+	defer server.Close()
+
+	client := New(context.Background(), "key", "test", WithBaseUrl(server.URL+"/api"))
+
+	groups, err := client.ListUserGroups(context.Background(), ListOptions{Limit: 10, Filter: []string{"name:eq:group-07"}, Sort: []string{"-name", "id"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(groups) != 25 || groups[0].Name != "object-00" || groups[24].Name != "object-24" {
+		t.Errorf("Expected all 25 groups in order but got %d", len(groups))
+	}
+
+	// Two full pages and a short one that ends the listing
+	if len(queries) != 3 || queries[2].Get("skip") != "20" || queries[2].Get("limit") != "10" {
+		t.Fatalf("Expected 3 pages of 10 but got %v", queries)
+	}
+
+	if queries[0].Get("filter") != "name:eq:group-07" || queries[0].Get("sort") != "-name,id" {
+		t.Errorf("Expected the filter and comma separated sort on every page but got %v", queries[0])
+	}
+}
+
+func TestPaginationV1(t *testing.T) {
+	var queries []url.Values
+
+	// Exactly two full pages, which v1 ends through totalCount rather than a short page
+	server := newPagingServer(t, "/api/systemusers", 10, true, &queries)
+	defer server.Close()
+
+	client := New(context.Background(), "key", "test", WithBaseUrl(server.URL+"/api"))
+
+	var usernames []string
+	err := client.ForEachUser(context.Background(), ListOptions{Limit: 5, Sort: []string{"-username", "email"}}, func(user User) error {
+		usernames = append(usernames, user.Username)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(usernames) != 10 || usernames[0] != "user-00" || usernames[9] != "user-09" {
+		t.Errorf("Expected the 10 users in order but got %v", usernames)
+	}
+
+	if len(queries) != 2 || queries[0].Get("sort") != "-username email" {
+		t.Errorf("Expected 2 pages with a space separated sort but got %v", queries)
+	}
+
+	var seen int
+	err = client.ForEachUser(context.Background(), ListOptions{Limit: 5}, func(user User) error {
+		seen++
+		if seen == 7 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil || seen != 7 {
+		t.Errorf("Expected to stop after 7 users but saw %d (%v)", seen, err)
+	}
+
+	boom := errors.New("boom")
+	if err := client.ForEachUser(context.Background(), ListOptions{}, func(User) error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("Expected the callback error to be returned but got %v", err)
+	}
+}
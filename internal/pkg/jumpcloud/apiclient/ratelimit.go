@@ -0,0 +1,96 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_REQUESTS_PER_SECOND = 10
+)
+
+// rateLimiter is a token bucket holding up to burst tokens that refills at rate
+// tokens per second. A single limiter is shared by every request the provider
+// makes, so resources running in parallel cannot burst past JumpCloud's limits.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to
+// wait before it may use it. The bucket may go negative, which queues callers
+// in the order they arrived.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel hands back a token that was reserved but never used
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitTransport waits for the shared limiter before every attempt. It sits
+// below the retry transport so that retries are throttled as well.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(request.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(request)
+}
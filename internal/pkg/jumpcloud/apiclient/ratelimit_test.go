@@ -0,0 +1,79 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := newRateLimiter(1, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected the burst to pass without waiting but took %s", elapsed)
+	}
+}
+
+func TestRateLimiterThrottlesConcurrentCallers(t *testing.T) {
+	limiter := newRateLimiter(50, 1)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.Wait(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	// One token is available straight away, the other five refill at 50/s
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected 6 requests at 50/s to take at least 100ms but took %s", elapsed)
+	}
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected %s but got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestClientSharesRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := New(context.Background(), "key", "test", WithBaseUrl(server.URL), WithRateLimit(20))
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		response, err := client.HTTPClient().Get(server.URL)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		response.Body.Close()
+	}
+
+	// 20 requests fit in the initial burst, the remaining 5 refill at 20/s
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected requests to be throttled but 25 requests took %s", elapsed)
+	}
+}
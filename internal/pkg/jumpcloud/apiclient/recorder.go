@@ -0,0 +1,272 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	RECORDER_MODE_RECORD = "record"
+	RECORDER_MODE_REPLAY = "replay"
+
+	// Value written to cassettes in place of secret header values
+	RECORDER_REDACTED = REDACTED
+)
+
+type (
+	// Cassette holds the request/response pairs of one recording
+	Cassette struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+
+	Interaction struct {
+		Request  RecordedRequest  `json:"request"`
+		Response RecordedResponse `json:"response"`
+	}
+
+	RecordedRequest struct {
+		Method  string      `json:"method"`
+		URL     string      `json:"url"`
+		Headers http.Header `json:"headers,omitempty"`
+		Body    string      `json:"body,omitempty"`
+	}
+
+	RecordedResponse struct {
+		StatusCode int         `json:"status_code"`
+		Status     string      `json:"status"`
+		Headers    http.Header `json:"headers,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+
+	// recorderTransport writes every interaction to a cassette file in record mode and
+	// answers requests from that file, without touching the network, in replay mode
+	recorderTransport struct {
+		next     http.RoundTripper
+		cassette *openCassette
+	}
+
+	// openCassette is a cassette in use by the current process. Terraform configures a
+	// new provider, and so a new client, for every command of an acceptance test, they
+	// all share the one cassette of the test so that every command is recorded and each
+	// recorded interaction is only replayed once.
+	openCassette struct {
+		mode string
+		path string
+
+		mu       sync.Mutex
+		cassette Cassette
+		used     []bool
+	}
+)
+
+var (
+	openCassettesMu sync.Mutex
+	openCassettes   = map[string]*openCassette{}
+)
+
+func newRecorderTransport(next http.RoundTripper, mode string, path string) (*recorderTransport, error) {
+	if mode != RECORDER_MODE_RECORD && mode != RECORDER_MODE_REPLAY {
+		return nil, fmt.Errorf("unknown recorder mode %q, expected %q or %q", mode, RECORDER_MODE_RECORD, RECORDER_MODE_REPLAY)
+	}
+
+	cassette, err := useCassette(mode, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorderTransport{next: next, cassette: cassette}, nil
+}
+
+// useCassette returns the cassette already open for path or opens it. Recording starts
+// from an empty cassette the first time a path is used, replacing an earlier recording.
+func useCassette(mode string, path string) (*openCassette, error) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	openCassettesMu.Lock()
+	defer openCassettesMu.Unlock()
+
+	if open, ok := openCassettes[absolute]; ok && open.mode == mode {
+		return open, nil
+	}
+
+	open := &openCassette{mode: mode, path: path}
+
+	if mode == RECORDER_MODE_REPLAY {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cassette: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &open.cassette); err != nil {
+			return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+		}
+
+		open.used = make([]bool, len(open.cassette.Interactions))
+	}
+
+	openCassettes[absolute] = open
+
+	return open, nil
+}
+
+func (t *recorderTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request bodies are compared redacted, the way they were recorded
+	body = redactJSON([]byte(body))
+
+	if t.cassette.mode == RECORDER_MODE_REPLAY {
+		return t.cassette.replay(request, body)
+	}
+
+	return t.record(request, body)
+}
+
+func (t *recorderTransport) record(request *http.Request, body string) (*http.Response, error) {
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.cassette.mu.Lock()
+	defer t.cassette.mu.Unlock()
+
+	t.cassette.cassette.Interactions = append(t.cassette.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method:  request.Method,
+			URL:     request.URL.String(),
+			Headers: scrubHeaders(request.Header),
+			Body:    body,
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Headers:    scrubHeaders(response.Header),
+			Body:       redactJSON(responseBody),
+		},
+	})
+
+	// The provider process has no shutdown hook, so the cassette is written after every interaction
+	if err := t.cassette.save(); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// replay answers with the first unused interaction for the same method and URL,
+// preferring one with the same body. Resources run in parallel, so interactions
+// for different URLs may be requested in a different order than they were recorded.
+func (t *openCassette) replay(request *http.Request, body string) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || interaction.Request.Method != request.Method || interaction.Request.URL != request.URL.String() {
+			continue
+		}
+
+		if interaction.Request.Body == body {
+			match = i
+			break
+		}
+
+		if match < 0 {
+			match = i
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("no unused interaction for %s %s in cassette %s", request.Method, request.URL.String(), t.path)
+	}
+
+	t.used[match] = true
+	recorded := t.cassette.Interactions[match].Response
+
+	return &http.Response{
+		StatusCode:    recorded.StatusCode,
+		Status:        recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       request,
+	}, nil
+}
+
+func (t *openCassette) save() error {
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.path, data, 0644)
+}
+
+// readRequestBody returns the request body, reading it from a copy when the request
+// can provide one and otherwise leaving an unread copy on the request
+func readRequestBody(request *http.Request) (string, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return "", nil
+	}
+
+	if request.GetBody != nil {
+		copied, err := request.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer copied.Close()
+
+		body, err := io.ReadAll(copied)
+		return string(body), err
+	}
+
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return "", err
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return string(body), nil
+}
+
+// errorTransport fails every request, it stands in for a transport that could not be set up
+type errorTransport struct {
+	err error
+}
+
+func (t errorTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
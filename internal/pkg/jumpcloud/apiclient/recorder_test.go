@@ -0,0 +1,148 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	const apiKey = "super-secret-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"_id":"5fa000000000000000000001","username":"jdoe"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	recording := New(context.Background(), apiKey, "test", WithBaseUrl(server.URL), WithRecorder(RECORDER_MODE_RECORD, cassette))
+
+	created, _, err := recording.CreateUser(context.Background(), &User{Username: "jdoe"})
+	if err != nil || created.Id != "5fa000000000000000000001" {
+		t.Fatalf("Unexpected result while recording: %#v (%v)", created, err)
+	}
+
+	if _, _, err := recording.GetUserDetails(context.Background(), "missing"); !IsNotFound(err) {
+		t.Fatalf("Expected a 404 while recording but got %v", err)
+	}
+
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("Expected a cassette to be written: %s", err)
+	}
+
+	if strings.Contains(string(data), apiKey) {
+		t.Errorf("Expected the API key to be scrubbed from the cassette")
+	}
+
+	if !strings.Contains(string(data), RECORDER_REDACTED) {
+		t.Errorf("Expected the x-api-key header to be recorded as %s", RECORDER_REDACTED)
+	}
+
+	// The server is gone, so these can only be answered from the cassette
+	replaying := New(context.Background(), "another-key", "test", WithBaseUrl(server.URL), WithRecorder(RECORDER_MODE_REPLAY, cassette))
+
+	replayed, _, err := replaying.CreateUser(context.Background(), &User{Username: "jdoe"})
+	if err != nil || replayed.Id != created.Id {
+		t.Errorf("Expected the recorded user but got %#v (%v)", replayed, err)
+	}
+
+	if _, _, err := replaying.GetUserDetails(context.Background(), "missing"); !IsNotFound(err) {
+		t.Errorf("Expected the recorded 404 but got %v", err)
+	}
+
+	// Every interaction is only replayed once
+	if _, _, err := replaying.GetUserDetails(context.Background(), "missing"); err == nil || IsNotFound(err) {
+		t.Errorf("Expected an error for a request missing from the cassette but got %v", err)
+	}
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	client := New(context.Background(), "key", "test", WithRecorder(RECORDER_MODE_REPLAY, filepath.Join(t.TempDir(), "missing.json")))
+
+	if _, _, err := client.GetUserDetails(context.Background(), "5fa000000000000000000001"); err == nil || !strings.Contains(err.Error(), "unable to read cassette") {
+		t.Errorf("Expected the missing cassette to be reported but got %v", err)
+	}
+}
+
+// Terraform configures a new provider for every command of an acceptance test, each of
+// them has to add to the same cassette and replay only what it has not used yet
+func TestRecorderSharesCassetteBetweenClients(t *testing.T) {
+	users := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			users["5fa000000000000000000001"] = "jdoe"
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"_id":"5fa000000000000000000001","username":"jdoe","password":"hunter2"}`))
+			return
+		}
+
+		if _, ok := users["5fa000000000000000000001"]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+
+		w.Write([]byte(`{"_id":"5fa000000000000000000001","username":"jdoe"}`))
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "shared.json")
+
+	// A read before the user exists, then a second client, as for the next step, creates and reads it
+	first := New(context.Background(), "key", "test", WithBaseUrl(server.URL), WithRecorder(RECORDER_MODE_RECORD, cassette))
+	if _, _, err := first.GetUserDetails(context.Background(), "5fa000000000000000000001"); !IsNotFound(err) {
+		t.Fatalf("Expected a 404 while recording but got %v", err)
+	}
+
+	second := New(context.Background(), "key", "test", WithBaseUrl(server.URL), WithRecorder(RECORDER_MODE_RECORD, cassette))
+	if _, _, err := second.CreateUser(context.Background(), &User{Username: "jdoe", Password: "hunter2"}); err != nil {
+		t.Fatalf("Unexpected error while recording: %s", err)
+	}
+	if _, _, err := second.GetUserDetails(context.Background(), "5fa000000000000000000001"); err != nil {
+		t.Fatalf("Unexpected error while recording: %s", err)
+	}
+
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("Expected a cassette to be written: %s", err)
+	}
+
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("Expected the password to be redacted from the request and response bodies in\n%s", data)
+	}
+
+	if count := strings.Count(string(data), `"method"`); count != 3 {
+		t.Errorf("Expected the interactions of both clients in the cassette but got %d", count)
+	}
+
+	first = New(context.Background(), "key", "test", WithBaseUrl(server.URL), WithRecorder(RECORDER_MODE_REPLAY, cassette))
+	if _, _, err := first.GetUserDetails(context.Background(), "5fa000000000000000000001"); !IsNotFound(err) {
+		t.Errorf("Expected the recorded 404 but got %v", err)
+	}
+
+	second = New(context.Background(), "key", "test", WithBaseUrl(server.URL), WithRecorder(RECORDER_MODE_REPLAY, cassette))
+	if _, _, err := second.CreateUser(context.Background(), &User{Username: "jdoe", Password: "hunter2"}); err != nil {
+		t.Errorf("Expected the recorded user but got %v", err)
+	}
+
+	// The 404 was used up by the first client, so the second one gets the user
+	if user, _, err := second.GetUserDetails(context.Background(), "5fa000000000000000000001"); err != nil || user.Username != "jdoe" {
+		t.Errorf("Expected the recorded user but got %#v (%v)", user, err)
+	}
+}
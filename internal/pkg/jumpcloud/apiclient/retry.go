@@ -0,0 +1,174 @@
+package apiclient
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DEFAULT_MAX_RETRIES    = 5
+	DEFAULT_RETRY_MAX_WAIT = 30 * time.Second
+	DEFAULT_RETRY_MIN_WAIT = 1 * time.Second
+)
+
+// retryTransport retries requests that JumpCloud rejected because of rate limiting
+// or a transient gateway error, waiting with exponential backoff in between.
+//
+// A 429 means the request was never processed, so it is retried for every method.
+// 502/503/504 responses and connection resets are only retried for idempotent
+// methods, as a POST may already have been applied.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	minWait := DEFAULT_RETRY_MIN_WAIT
+	if maxWait < minWait {
+		minWait = maxWait
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	attemptRequest := request
+
+	for attempt := 0; ; attempt++ {
+		response, err := t.next.RoundTrip(attemptRequest)
+
+		if attempt >= t.maxRetries || !t.shouldRetry(request, response, err) {
+			return response, err
+		}
+
+		// Without GetBody the request body cannot be replayed
+		if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+			return response, err
+		}
+
+		wait := t.backoff(attempt, response)
+
+		fields := map[string]interface{}{
+			"method":  request.Method,
+			"url":     request.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if response != nil {
+			fields["status"] = response.Status
+		}
+		if err != nil {
+			fields["err"] = err.Error()
+		}
+		tflog.SubsystemWarn(request.Context(), SUBSYSTEM_NAME, "Retrying request to JumpCloud API", fields)
+
+		if response != nil {
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+
+		attemptRequest = request.Clone(request.Context())
+		if request.GetBody != nil {
+			if attemptRequest.Body, err = request.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(request *http.Request, response *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(request.Method) && isConnectionReset(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(request.Method)
+	}
+
+	return false
+}
+
+// backoff honours Retry-After when the server sends it and otherwise waits
+// minWait * 2^attempt with jitter, never more than maxWait
+func (t *retryTransport) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if wait, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.maxWait
+	if attempt < 32 && t.minWait<<uint(attempt) < t.maxWait {
+		wait = t.minWait << uint(attempt)
+	}
+
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	return wait
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,110 @@
+package apiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient(maxRetries int) *http.Client {
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, 50*time.Millisecond)
+	transport.minWait = time.Millisecond
+
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		statuses   []int
+		maxRetries int
+		expect     int
+		calls      int32
+	}{
+		"success":                 {method: http.MethodGet, statuses: []int{200}, maxRetries: 3, expect: 200, calls: 1},
+		"retries service errors":  {method: http.MethodGet, statuses: []int{503, 502, 200}, maxRetries: 3, expect: 200, calls: 3},
+		"retries rate limit":      {method: http.MethodPost, statuses: []int{429, 201}, maxRetries: 3, expect: 201, calls: 2},
+		"post not retried on 503": {method: http.MethodPost, statuses: []int{503, 201}, maxRetries: 3, expect: 503, calls: 1},
+		"client errors returned":  {method: http.MethodPut, statuses: []int{400, 200}, maxRetries: 3, expect: 400, calls: 1},
+		"gives up":                {method: http.MethodDelete, statuses: []int{504, 504, 504}, maxRetries: 2, expect: 504, calls: 3},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+
+				if body := r.Header.Get("Content-Length"); r.Method != http.MethodGet && body == "0" {
+					t.Errorf("request body was not replayed on attempt %d", call)
+				}
+
+				w.WriteHeader(test.statuses[call-1])
+			}))
+			defer server.Close()
+
+			request, _ := http.NewRequest(test.method, server.URL, strings.NewReader(`{"name":"test"}`))
+			response, err := newTestRetryClient(test.maxRetries).Do(request)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			response.Body.Close()
+
+			if response.StatusCode != test.expect {
+				t.Errorf("Expected status %d but got %d", test.expect, response.StatusCode)
+			}
+
+			if calls != test.calls {
+				t.Errorf("Expected %d calls but got %d", test.calls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRespectsRetryAfter(t *testing.T) {
+	var first time.Time
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		if waited := time.Since(first); waited < 900*time.Millisecond {
+			t.Errorf("Expected to wait for Retry-After but retried after %s", waited)
+		}
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, 1, 2*time.Second)
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 but got %d", response.StatusCode)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("3"); !ok || wait != 3*time.Second {
+		t.Errorf("Expected 3s but got %s (%v)", wait, ok)
+	}
+
+	if wait, ok := parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)); !ok || wait != 0 {
+		t.Errorf("Expected a date in the past to wait 0s but got %s (%v)", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("Expected an invalid Retry-After to be ignored")
+	}
+}
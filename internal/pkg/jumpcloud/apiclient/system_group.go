@@ -0,0 +1,113 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	systemGroupApiVersion  = "v2"
+	systemGroupApiEndpoint = "systemgroups"
+
+	// How devices become members of a group: explicitly, or by matching the
+	// group's member query with or without an administrator approving suggestions
+	MEMBERSHIP_METHOD_STATIC                  = "STATIC"
+	MEMBERSHIP_METHOD_DYNAMIC_REVIEW_REQUIRED = "DYNAMIC_REVIEW_REQUIRED"
+	MEMBERSHIP_METHOD_DYNAMIC_AUTOMATED       = "DYNAMIC_AUTOMATED"
+
+	MEMBER_QUERY_TYPE_FILTER = "FilterQuery"
+)
+
+type (
+	SystemGroup struct {
+		Attributes              map[string]interface{}  `json:"attributes,omitempty"`
+		Description             string                  `json:"description,omitempty"`
+		Email                   string                  `json:"email,omitempty"`
+		Id                      string                  `json:"id,omitempty"`
+		MemberQuery             *SystemGroupMemberQuery `json:"memberQuery,omitempty"`
+		MemberSuggestionsNotify bool                    `json:"memberSuggestionsNotify"`
+		MembershipMethod        string                  `json:"membershipMethod,omitempty"`
+		Name                    string                  `json:"name"`
+		Type                    string                  `json:"type,omitempty"`
+	}
+
+	// SystemGroupMemberQuery selects the devices of a dynamic group, a device has to
+	// match every filter
+	SystemGroupMemberQuery struct {
+		QueryType string        `json:"queryType,omitempty"`
+		Filters   []QueryFilter `json:"filters,omitempty"`
+	}
+)
+
+func (c *Client) CreateSystemGroup(ctx context.Context, create *SystemGroup) (SystemGroup, *http.Response, error) {
+	return Create(ctx, c, systemGroupApiVersion, systemGroupApiEndpoint, create)
+}
+
+func (c *Client) GetSystemGroupDetails(ctx context.Context, id string) (SystemGroup, *http.Response, error) {
+	return Get[SystemGroup](ctx, c, systemGroupApiVersion, fmt.Sprintf("%s/%s", systemGroupApiEndpoint, id), nil)
+}
+
+// ListSystemGroups returns every system group matching options, eg Filter: []string{"name:eq:servers"}
+func (c *Client) ListSystemGroups(ctx context.Context, options ListOptions) ([]SystemGroup, error) {
+	return ListAll[SystemGroup](ctx, c, systemGroupApiVersion, systemGroupApiEndpoint, options)
+}
+
+// GetSystemGroupByName looks up a single system group by its exact name
+func (c *Client) GetSystemGroupByName(ctx context.Context, name string) (payload SystemGroup, response *http.Response, err error) {
+	query := url.Values{}
+	query.Set("filter", fmt.Sprintf("name:eq:%s", name))
+	query.Set("limit", "2")
+
+	groups, response, err := List[SystemGroup](ctx, c, systemGroupApiVersion, systemGroupApiEndpoint, query)
+	if err != nil {
+		return payload, response, err
+	}
+
+	if len(groups) != 1 {
+		return payload, response, fmt.Errorf("expected exactly one system group named %q, found %d", name, len(groups))
+	}
+
+	return groups[0], response, nil
+}
+
+func (c *Client) UpdateSystemGroup(ctx context.Context, update *SystemGroup) (SystemGroup, *http.Response, error) {
+	return Update(ctx, c, systemGroupApiVersion, fmt.Sprintf("%s/%s", systemGroupApiEndpoint, update.Id), update)
+}
+
+func (c *Client) DeleteSystemGroup(ctx context.Context, id string) (*http.Response, error) {
+	return Delete(ctx, c, systemGroupApiVersion, fmt.Sprintf("%s/%s", systemGroupApiEndpoint, id))
+}
+
+func (c *Client) ListSystemGroupMembers(ctx context.Context, groupId string) (systemIds []string, err error) {
+	members, err := c.ListGraphMembers(ctx, systemGroupApiEndpoint, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	systemIds = []string{}
+	for _, member := range members {
+		if member.To.Type == "system" {
+			systemIds = append(systemIds, member.To.Id)
+		}
+	}
+
+	return systemIds, nil
+}
+
+func (c *Client) AddSystemGroupMember(ctx context.Context, groupId string, systemId string) (*http.Response, error) {
+	return c.ModifyGraphMember(ctx, systemGroupApiEndpoint, groupId, GraphOperation{
+		Op:   GRAPH_OP_ADD,
+		Type: "system",
+		Id:   systemId,
+	})
+}
+
+func (c *Client) RemoveSystemGroupMember(ctx context.Context, groupId string, systemId string) (*http.Response, error) {
+	return c.ModifyGraphMember(ctx, systemGroupApiEndpoint, groupId, GraphOperation{
+		Op:   GRAPH_OP_REMOVE,
+		Type: "system",
+		Id:   systemId,
+	})
+}
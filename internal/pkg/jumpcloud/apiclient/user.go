@@ -0,0 +1,118 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// v1 endpoints live directly under the API root
+	userApiVersion  = ""
+	userApiEndpoint = "systemusers"
+)
+
+// Fields of a user that member queries can filter on
+var UserQueryFields = []string{
+	"username", "email", "alternateEmail", "firstname", "middlename", "lastname", "displayname",
+	"description", "company", "costCenter", "department", "employeeIdentifier", "employeeType",
+	"jobTitle", "location", "manager", "state", "created", "suspended", "account_locked", "activated",
+	"password_never_expires", "ldap_binding_user", "samba_service_user", "sudo", "passwordless_sudo",
+	"totp_enabled", "unix_uid", "unix_guid", "addresses.locality", "addresses.region",
+	"addresses.postalCode", "addresses.country",
+}
+
+type (
+	User struct {
+		Id                          string          `json:"_id,omitempty"`
+		Username                    string          `json:"username"`
+		Email                       string          `json:"email"`
+		Firstname                   string          `json:"firstname"`
+		Middlename                  string          `json:"middlename"`
+		Lastname                    string          `json:"lastname"`
+		Displayname                 string          `json:"displayname"`
+		Password                    string          `json:"password,omitempty"`
+		Description                 string          `json:"description"`
+		Company                     string          `json:"company"`
+		CostCenter                  string          `json:"costCenter"`
+		Department                  string          `json:"department"`
+		EmployeeIdentifier          string          `json:"employeeIdentifier"`
+		EmployeeType                string          `json:"employeeType"`
+		JobTitle                    string          `json:"jobTitle"`
+		Location                    string          `json:"location"`
+		Sudo                        bool            `json:"sudo"`
+		PasswordlessSudo            bool            `json:"passwordless_sudo"`
+		AccountLocked               bool            `json:"account_locked"`
+		Suspended                   bool            `json:"suspended"`
+		Activated                   bool            `json:"activated,omitempty"`
+		PasswordNeverExpires        bool            `json:"password_never_expires"`
+		LdapBindingUser             bool            `json:"ldap_binding_user"`
+		SambaServiceUser            bool            `json:"samba_service_user"`
+		EnableUserPortalMultifactor bool            `json:"enable_user_portal_multifactor"`
+		TotpEnabled                 bool            `json:"totp_enabled,omitempty"`
+		Mfa                         *UserMfa        `json:"mfa,omitempty"`
+		UnixUid                     int64           `json:"unix_uid,omitempty"`
+		UnixGuid                    int64           `json:"unix_guid,omitempty"`
+		Attributes                  []UserAttribute `json:"attributes"`
+	}
+
+	UserMfa struct {
+		Configured     bool   `json:"configured,omitempty"`
+		Exclusion      bool   `json:"exclusion"`
+		ExclusionUntil string `json:"exclusionUntil,omitempty"`
+	}
+
+	UserAttribute struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	UserList struct {
+		TotalCount int64  `json:"totalCount"`
+		Results    []User `json:"results"`
+	}
+)
+
+func (c *Client) CreateUser(ctx context.Context, create *User) (User, *http.Response, error) {
+	return Create(ctx, c, userApiVersion, userApiEndpoint, create)
+}
+
+func (c *Client) GetUserDetails(ctx context.Context, id string) (User, *http.Response, error) {
+	return Get[User](ctx, c, userApiVersion, fmt.Sprintf("%s/%s", userApiEndpoint, id), nil)
+}
+
+// GetUserByUsername looks up a single user by its exact username
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (payload User, response *http.Response, err error) {
+	query := url.Values{}
+	query.Set("filter", fmt.Sprintf("username:$eq:%s", username))
+	query.Set("limit", "2")
+
+	list, response, err := Get[UserList](ctx, c, userApiVersion, userApiEndpoint, query)
+	if err != nil {
+		return payload, response, err
+	}
+
+	if len(list.Results) != 1 {
+		return payload, response, fmt.Errorf("expected exactly one user with username %q, found %d", username, len(list.Results))
+	}
+
+	return list.Results[0], response, nil
+}
+
+// ForEachUser calls fn for every user matching options, eg Filter: []string{"department:$eq:Engineering"},
+// fetching one page at a time
+func (c *Client) ForEachUser(ctx context.Context, options ListOptions, fn func(User) error) error {
+	return ForEach(ctx, c, userApiVersion, userApiEndpoint, options, fn)
+}
+
+func (c *Client) UpdateUser(ctx context.Context, update *User) (User, *http.Response, error) {
+	body := *update
+	body.Id = ""
+
+	return Update(ctx, c, userApiVersion, fmt.Sprintf("%s/%s", userApiEndpoint, update.Id), &body)
+}
+
+func (c *Client) DeleteUser(ctx context.Context, id string) (*http.Response, error) {
+	return Delete(ctx, c, userApiVersion, fmt.Sprintf("%s/%s", userApiEndpoint, id))
+}
@@ -1,19 +1,27 @@
 package apiclient
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
-
-	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/url"
 )
 
 const (
 	apiVersion  = "v2"
 	apiEndpoint = "usergroups"
+
+	MEMBER_QUERY_TYPE_SEARCH = "SearchQuery"
+
+	// How a SearchQuery combines its conditions
+	SEARCH_FILTER_AND = "and"
+	SEARCH_FILTER_OR  = "or"
+
+	// Whether a member query exception adds a user that does not match the query
+	// or keeps out a user that does
+	MEMBER_QUERY_EXCEPTION_INCLUDE = "INCLUDE"
+	MEMBER_QUERY_EXCEPTION_EXCLUDE = "EXCLUDE"
 )
 
 type (
@@ -37,11 +45,16 @@ type (
 		PosixGroups  []PosixGroup           `json:"posixGroups,omitempty"`
 		Radius       *UserGroupRadiusConfig `json:"radius,omitempty"`
 		SambaEnabled bool                   `json:"sambaEnabled,omitempty"`
+
+		// Custom attributes are stored next to the fields above in the same JSON
+		// object, any key that is not one of them ends up here
+		Custom map[string]interface{} `json:"-"`
 	}
 
+	// UserGroupSudoConfig always sends both settings, so that sudo can be turned off
 	UserGroupSudoConfig struct {
-		Enabled         bool `json:"enabled,omitempty"`
-		WithoutPassword bool `json:"withoutPassword,omitempty"`
+		Enabled         bool `json:"enabled"`
+		WithoutPassword bool `json:"withoutPassword"`
 	}
 
 	LdapGroup struct {
@@ -62,21 +75,36 @@ type (
 		Value string `json:"value,omitempty"`
 	}
 
+	// UserGroupMemberQuery selects the users of a dynamic group. A FilterQuery matches
+	// users that match every one of Filters, a SearchQuery combines the conditions of
+	// SearchFilter with "and" or "or", eg {"or": [{"department": {"$eq": "IT"}}]}.
 	UserGroupMemberQuery struct {
-		QueryType string        `json:"queryType,omitempty"`
-		Filters   []QueryFilter `json:"filters,omitempty"`
+		QueryType    string                                 `json:"queryType,omitempty"`
+		Filters      []QueryFilter                          `json:"filters,omitempty"`
+		SearchFilter map[string][]map[string]SearchOperands `json:"filter,omitempty"`
 	}
 
+	// SearchOperands maps a search operator such as $eq or $in to its operand
+	SearchOperands map[string]interface{}
+
 	QueryFilter struct {
 		Field    string `json:"field,omitempty"`
 		Operator string `json:"operator,omitempty"`
 		Value    string `json:"value,omitempty"`
 	}
 
+	// UserGroupMemberQueryExceptions is a user whose membership is decided explicitly
+	// instead of by the member query
 	UserGroupMemberQueryExceptions struct {
-		Attributes []interface{} `json:"attributes,omitempty"`
-		Id         string        `json:"id,omitempty"`
-		Type       string        `json:"type,omitempty"`
+		Attributes *MemberQueryExceptionAttributes `json:"attributes,omitempty"`
+		Id         string                          `json:"id,omitempty"`
+		Type       string                          `json:"type,omitempty"`
+	}
+
+	MemberQueryExceptionAttributes struct {
+		// MEMBER_QUERY_EXCEPTION_INCLUDE or MEMBER_QUERY_EXCEPTION_EXCLUDE, exceptions
+		// without it exclude the user
+		Membership string `json:"membership,omitempty"`
 	}
 
 	UserGroupMemberSuggestionCounts struct {
@@ -86,137 +114,128 @@ type (
 	}
 )
 
-func (c *Client) CallApiWithBody(method string, group *UserGroup) (payload UserGroup, response *http.Response, err error) {
-	tflog.SubsystemInfo(c.Context, SUBSYSTEM_NAME, "Upsert UserGroup named "+group.Name, map[string]interface{}{
-		"client":  "UserGroup",
-		"func":    "CallApiWithBody",
-		"method":  method,
-		"payload": c.getPayloadAsString(payload),
-	})
+// Keys of the user group attributes object that JumpCloud itself interprets,
+// they cannot be used as custom attributes
+var UserGroupReservedAttributes = []string{"sudo", "ldapGroups", "posixGroups", "radius", "sambaEnabled"}
 
-	endpoint := apiEndpoint
-	if group.Id != "" {
-		endpoint = fmt.Sprintf("%s/%s", apiEndpoint, group.Id)
-	}
+// userGroupAttributes has the same fields as UserGroupAttributes without its JSON
+// methods, so they can use the default encoding for the known fields
+type userGroupAttributes UserGroupAttributes
 
-	request, err := c.prepareRequest(method, apiVersion, endpoint, group, nil, nil)
-	if err != nil {
-		return payload, nil, err
+func (a UserGroupAttributes) MarshalJSON() ([]byte, error) {
+	known, err := json.Marshal(userGroupAttributes(a))
+	if err != nil || len(a.Custom) == 0 {
+		return known, err
 	}
 
-	request_reader := c.ReusableReader(request.Body)
-	request.Body = io.NopCloser(request_reader)
-	tflog.SubsystemInfo(c.Context, SUBSYSTEM_NAME, "Sending Request", map[string]interface{}{
-		"client": "UserGroup",
-		"func":   "CallApiWithBody",
-		"method": request.Method,
-		"url":    request.URL.String(),
-		"body":   c.ReadBody(request_reader),
-	})
+	merged := map[string]interface{}{}
+	if err := json.Unmarshal(known, &merged); err != nil {
+		return nil, err
+	}
 
-	response, err = c.httpClient.Do(request)
+	for name, value := range a.Custom {
+		if _, ok := merged[name]; !ok {
+			merged[name] = value
+		}
+	}
 
-	response_reader := c.ReusableReader(response.Body)
-	response.Body = io.NopCloser(response_reader)
+	return json.Marshal(merged)
+}
 
-	tflog.SubsystemInfo(c.Context, SUBSYSTEM_NAME, "Got Response from API", map[string]interface{}{
-		"client":   "UserGroup",
-		"func":     "CallApiWithBody",
-		"method":   request.Method,
-		"url":      request.URL.String(),
-		"request":  c.ReadBody(request_reader),
-		"status":   response.Status,
-		"response": c.ReadBody(response_reader),
-		"err":      err,
-	})
+func (a *UserGroupAttributes) UnmarshalJSON(data []byte) error {
+	var known userGroupAttributes
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
 
-	if err != nil || response == nil {
-		return payload, response, err
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
 	}
 
-	defer response.Body.Close()
+	for _, name := range UserGroupReservedAttributes {
+		delete(all, name)
+	}
 
-	if response.StatusCode >= 300 {
-		body, _ := io.ReadAll(response.Body)
-		tflog.SubsystemInfo(c.Context, SUBSYSTEM_NAME, "Got Response from API", map[string]interface{}{
-			"client":        "UserGroup",
-			"func":          "CallApiWithBody",
-			"method":        request.Method,
-			"url":           request.URL.String(),
-			"request_body":  c.getPayloadAsString(payload),
-			"status":        response.Status,
-			"response_body": body,
-			"err":           err,
-		})
-		return payload, response, fmt.Errorf("status: %v, body: %s", response.StatusCode, body)
+	*a = UserGroupAttributes(known)
+	if len(all) > 0 {
+		a.Custom = all
 	}
 
-	// if err = json.NewDecoder(response.Body).Decode(&payload); err != nil {
-	// 	return payload, response, err
-	// }
+	return nil
+}
+
+func (c *Client) CreateUserGroup(ctx context.Context, create *UserGroup) (UserGroup, *http.Response, error) {
+	return Create(ctx, c, apiVersion, apiEndpoint, create)
+}
 
-	response_buf, _ := io.ReadAll(response_reader)
-	err = json.Unmarshal(response_buf, &payload)
-	if err != nil {
-		tflog.SubsystemError(c.Context, SUBSYSTEM_NAME, "Error while Unmarshalling Response", map[string]interface{}{
-			"client":   "UserGroup",
-			"func":     "CallApiWithBody",
-			"response": c.ReadBody(response_reader),
-			"err":      err,
-		})
-	}
-
-	jsonPayload, _ := json.Marshal(payload)
-	tflog.SubsystemInfo(c.Context, SUBSYSTEM_NAME, "Unmarshalled Response into UserGroup", map[string]interface{}{
-		"client":    "UserGroup",
-		"func":      "CallApiWithBody",
-		"response":  c.ReadBody(response_reader),
-		"usergroup": string(jsonPayload),
-	})
+func (c *Client) GetUserGroupDetails(ctx context.Context, id string) (UserGroup, *http.Response, error) {
+	return Get[UserGroup](ctx, c, apiVersion, fmt.Sprintf("%s/%s", apiEndpoint, id), nil)
+}
 
-	return payload, response, err
+func (c *Client) DeleteUserGroup(ctx context.Context, id string) (*http.Response, error) {
+	return Delete(ctx, c, apiVersion, fmt.Sprintf("%s/%s", apiEndpoint, id))
 }
 
-func (c *Client) CallApiWithID(method string, id string) (payload UserGroup, response *http.Response, err error) {
-	endpoint := fmt.Sprintf("%s/%s", apiEndpoint, id)
-	request, err := c.prepareRequest(method, apiVersion, endpoint, nil, nil, nil)
-	if err != nil {
-		return payload, nil, err
-	}
+func (c *Client) UpdateUserGroup(ctx context.Context, update *UserGroup) (UserGroup, *http.Response, error) {
+	return Update(ctx, c, apiVersion, fmt.Sprintf("%s/%s", apiEndpoint, update.Id), update)
+}
+
+// ListUserGroups returns every user group matching options, eg Filter: []string{"name:eq:admins"}
+func (c *Client) ListUserGroups(ctx context.Context, options ListOptions) ([]UserGroup, error) {
+	return ListAll[UserGroup](ctx, c, apiVersion, apiEndpoint, options)
+}
 
-	response, err = c.httpClient.Do(request)
+// GetUserGroupByName looks up a single user group by its exact name
+func (c *Client) GetUserGroupByName(ctx context.Context, name string) (payload UserGroup, response *http.Response, err error) {
+	query := url.Values{}
+	query.Set("filter", fmt.Sprintf("name:eq:%s", name))
+	query.Set("limit", "2")
 
-	if err != nil || response == nil {
+	groups, response, err := List[UserGroup](ctx, c, apiVersion, apiEndpoint, query)
+	if err != nil {
 		return payload, response, err
 	}
 
-	defer response.Body.Close()
-
-	if response.StatusCode >= 300 {
-		body, _ := ioutil.ReadAll(response.Body)
-		return payload, response, errors.New(fmt.Sprintf("Status: %v, Body: %s", response.StatusCode, body))
+	if len(groups) == 0 {
+		return payload, response, fmt.Errorf("no user group named %q: %w", name, ErrNotFound)
 	}
 
-	if err = json.NewDecoder(response.Body).Decode(&payload); err != nil {
-		return payload, response, err
+	if len(groups) > 1 {
+		return payload, response, fmt.Errorf("expected exactly one user group named %q, found %d", name, len(groups))
 	}
 
-	return payload, response, err
+	return groups[0], response, nil
 }
 
-func (c *Client) CreateUserGroup(create *UserGroup) (UserGroup, *http.Response, error) {
-	return c.CallApiWithBody(http.MethodPost, create)
-}
+func (c *Client) ListUserGroupMembers(ctx context.Context, groupId string) (userIds []string, err error) {
+	members, err := c.ListGraphMembers(ctx, apiEndpoint, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds = []string{}
+	for _, member := range members {
+		if member.To.Type == "user" {
+			userIds = append(userIds, member.To.Id)
+		}
+	}
 
-func (c *Client) GetUserGroupDetails(id string) (UserGroup, *http.Response, error) {
-	return c.CallApiWithID(http.MethodGet, id)
+	return userIds, nil
 }
 
-func (c *Client) DeleteUserGroup(id string) (*http.Response, error) {
-	_, response, err := c.CallApiWithID(http.MethodDelete, id)
-	return response, err
+func (c *Client) AddUserGroupMember(ctx context.Context, groupId string, userId string) (*http.Response, error) {
+	return c.ModifyGraphMember(ctx, apiEndpoint, groupId, GraphOperation{
+		Op:   GRAPH_OP_ADD,
+		Type: "user",
+		Id:   userId,
+	})
 }
 
-func (c *Client) UpdateUserGroup(update *UserGroup) (UserGroup, *http.Response, error) {
-	return c.CallApiWithBody(http.MethodPut, update)
+func (c *Client) RemoveUserGroupMember(ctx context.Context, groupId string, userId string) (*http.Response, error) {
+	return c.ModifyGraphMember(ctx, apiEndpoint, groupId, GraphOperation{
+		Op:   GRAPH_OP_REMOVE,
+		Type: "user",
+		Id:   userId,
+	})
 }
@@ -0,0 +1,38 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserGroupAttributesCustomJSON(t *testing.T) {
+	body := `{"sambaEnabled":true,"sudo":{"enabled":true},"costCenter":"1234","priority":2}`
+
+	var attributes UserGroupAttributes
+	if err := json.Unmarshal([]byte(body), &attributes); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !attributes.SambaEnabled || attributes.Sudo == nil || !attributes.Sudo.Enabled {
+		t.Errorf("Expected the known attributes to be decoded but got %+v", attributes)
+	}
+
+	if len(attributes.Custom) != 2 || attributes.Custom["costCenter"] != "1234" || attributes.Custom["priority"] != float64(2) {
+		t.Errorf("Expected only the custom attributes in Custom but got %v", attributes.Custom)
+	}
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if string(encoded) != `{"costCenter":"1234","priority":2,"sambaEnabled":true,"sudo":{"enabled":true,"withoutPassword":false}}` {
+		t.Errorf("Expected the custom attributes next to the known ones but got %s", encoded)
+	}
+
+	// Custom attributes never replace the ones JumpCloud interprets
+	encoded, _ = json.Marshal(UserGroupAttributes{SambaEnabled: true, Custom: map[string]interface{}{"sambaEnabled": "no"}})
+	if string(encoded) != `{"sambaEnabled":true}` {
+		t.Errorf("Expected the known attribute to win but got %s", encoded)
+	}
+}
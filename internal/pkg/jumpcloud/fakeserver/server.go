@@ -0,0 +1,637 @@
+// Package fakeserver provides an in-memory stand-in for the parts of the JumpCloud
+// API used by the provider, so that resources can be tested without a live organization.
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Collections served by the fake server
+	UserGroups        = "usergroups"
+	SystemGroups      = "systemgroups"
+	ActiveDirectories = "activedirectories"
+	SystemUsers       = "systemusers"
+
+	// ApiKey is the key the fake server accepts unless Server.ApiKey is changed
+	ApiKey = "fake-api-key"
+
+	apiRoot = "/api"
+)
+
+type (
+	// Object is a JumpCloud object as it is stored and returned by the fake server
+	Object map[string]interface{}
+
+	Server struct {
+		*httptest.Server
+
+		// ApiKey expected in the x-api-key header, requests without it get a 401
+		ApiKey string
+
+		mu           sync.Mutex
+		lastId       uint64
+		collections  map[string]*collection
+		members      map[string][]member
+		associations map[string][]association
+	}
+
+	collection struct {
+		// v1 collections live directly under /api, use _id and wrap lists in totalCount/results
+		v1       bool
+		required []string
+		unique   string
+		// groups have a members sub-collection holding objects of this type
+		memberType string
+		objects    map[string]Object
+		order      []string
+	}
+
+	member struct {
+		Id   string `json:"id"`
+		Type string `json:"type"`
+	}
+
+	// association is a graph connection of an object, with the attributes of the connection
+	association struct {
+		To         member `json:"to"`
+		Attributes Object `json:"attributes,omitempty"`
+	}
+
+	graphOperation struct {
+		Op         string `json:"op"`
+		Type       string `json:"type"`
+		Id         string `json:"id"`
+		Attributes Object `json:"attributes,omitempty"`
+	}
+)
+
+// New starts a fake server. Close it when the test is done.
+func New() *Server {
+	s := &Server{
+		ApiKey: ApiKey,
+		collections: map[string]*collection{
+			UserGroups:        {required: []string{"name"}, unique: "name", memberType: "user"},
+			SystemGroups:      {required: []string{"name"}, unique: "name", memberType: "system"},
+			ActiveDirectories: {required: []string{"domain"}, unique: "domain"},
+			SystemUsers:       {v1: true, required: []string{"username", "email"}, unique: "username"},
+		},
+		members:      map[string][]member{},
+		associations: map[string][]association{},
+	}
+
+	for _, c := range s.collections {
+		c.objects = map[string]Object{}
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// BaseURL is the API root to configure the provider or client with, the equivalent
+// of https://console.jumpcloud.com/api
+func (s *Server) BaseURL() string {
+	return s.URL + apiRoot
+}
+
+// Seed stores an object as if it had been created through the API and returns it
+// with its generated id
+func (s *Server) Seed(name string, object Object) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collections[name]
+	stored := s.insert(c, copyObject(object))
+
+	return copyObject(stored)
+}
+
+// Get returns a copy of a stored object
+func (s *Server) Get(name string, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.collections[name].objects[id]
+	return copyObject(object), ok
+}
+
+// Remove deletes an object behind the provider's back, like a change made in the console
+func (s *Server) Remove(name string, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(s.collections[name], name, id)
+}
+
+// Edit merges fields into a stored object behind the provider's back, like a change
+// made in the console. It reports whether the object exists.
+func (s *Server) Edit(name string, id string, fields Object) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.collections[name].objects[id]
+	if !ok {
+		return false
+	}
+
+	for k, v := range copyObject(fields) {
+		object[k] = v
+	}
+
+	return true
+}
+
+// Association returns a copy of the attributes of the association between an object and
+// toId, and whether they are associated
+func (s *Server) Association(name string, id string, toId string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.associations[name+"/"+id] {
+		if a.To.Id == toId {
+			return copyObject(a.Attributes), true
+		}
+	}
+
+	return nil, false
+}
+
+// Members returns the ids of the members of a group
+func (s *Server) Members(name string, id string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := []string{}
+	for _, m := range s.members[name+"/"+id] {
+		ids = append(ids, m.Id)
+	}
+
+	return ids
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if s.ApiKey != "" && r.Header.Get("x-api-key") != s.ApiKey {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiRoot+"/") {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	v2 := false
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiRoot), "/"), "/")
+	if parts[0] == "v2" {
+		v2 = true
+		parts = parts[1:]
+	}
+
+	c, ok := s.collections[parts[0]]
+	if !ok || c.v1 == v2 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.list(w, r, c)
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.create(w, r, c)
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		s.get(w, c, parts[1])
+	case len(parts) == 2 && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+		s.update(w, r, c, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		s.delete(w, c, parts[0], parts[1])
+	case len(parts) == 3 && parts[2] == "members" && c.memberType != "":
+		s.handleMembers(w, r, c, parts[0], parts[1])
+	case len(parts) == 3 && parts[2] == "associations" && !c.v1:
+		s.handleAssociations(w, r, c, parts[0], parts[1])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, c *collection) {
+	query := r.URL.Query()
+
+	results := []Object{}
+	for _, id := range c.order {
+		if matchesFilters(c.objects[id], query["filter"]) {
+			results = append(results, c.objects[id])
+		}
+	}
+
+	sortObjects(results, query.Get("sort"))
+
+	total := len(results)
+
+	if skip, err := strconv.Atoi(query.Get("skip")); err == nil && skip > 0 {
+		if skip > len(results) {
+			skip = len(results)
+		}
+		results = results[skip:]
+	}
+
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+
+	if c.v1 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"totalCount": total,
+			"results":    results,
+		})
+		return
+	}
+
+	w.Header().Set("x-total-count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, c *collection) {
+	object, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	if message := s.validate(c, object, ""); message != "" {
+		status := http.StatusBadRequest
+		if strings.HasPrefix(message, "Conflict") {
+			status = http.StatusConflict
+		}
+		writeError(w, status, message)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.insert(c, object))
+}
+
+func (s *Server) get(w http.ResponseWriter, c *collection, id string) {
+	object, ok := c.objects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object)
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, c *collection, id string) {
+	existing, ok := c.objects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	object, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	// PATCH merges into the stored object, PUT replaces it
+	if r.Method == http.MethodPatch {
+		merged := copyObject(existing)
+		for k, v := range object {
+			merged[k] = v
+		}
+		object = merged
+	}
+
+	if message := s.validate(c, object, id); message != "" {
+		status := http.StatusBadRequest
+		if strings.HasPrefix(message, "Conflict") {
+			status = http.StatusConflict
+		}
+		writeError(w, status, message)
+		return
+	}
+
+	object[c.idField()] = id
+	c.objects[id] = object
+
+	writeJSON(w, http.StatusOK, object)
+}
+
+func (s *Server) delete(w http.ResponseWriter, c *collection, name string, id string) {
+	object, ok := c.objects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	s.remove(c, name, id)
+
+	writeJSON(w, http.StatusOK, object)
+}
+
+func (s *Server) handleMembers(w http.ResponseWriter, r *http.Request, c *collection, name string, id string) {
+	if _, ok := c.objects[id]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	key := name + "/" + id
+
+	switch r.Method {
+	case http.MethodGet:
+		connections := []interface{}{}
+		for _, m := range s.members[key] {
+			connections = append(connections, map[string]interface{}{
+				"to": m,
+			})
+		}
+
+		writeJSON(w, http.StatusOK, paginate(r, connections))
+
+	case http.MethodPost:
+		var operation graphOperation
+		if err := json.NewDecoder(r.Body).Decode(&operation); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+			return
+		}
+
+		if operation.Type != c.memberType || operation.Id == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Members of %s must be of type %s", name, c.memberType))
+			return
+		}
+
+		index := -1
+		for i, m := range s.members[key] {
+			if m.Id == operation.Id {
+				index = i
+			}
+		}
+
+		switch operation.Op {
+		case "add":
+			if index >= 0 {
+				writeError(w, http.StatusConflict, "Conflict: already a member")
+				return
+			}
+			s.members[key] = append(s.members[key], member{Id: operation.Id, Type: operation.Type})
+		case "remove":
+			if index < 0 {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			s.members[key] = append(s.members[key][:index], s.members[key][index+1:]...)
+		default:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported op %q", operation.Op))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// handleAssociations serves the graph associations of any v2 object, the associated
+// objects themselves are not checked
+func (s *Server) handleAssociations(w http.ResponseWriter, r *http.Request, c *collection, name string, id string) {
+	if _, ok := c.objects[id]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	key := name + "/" + id
+
+	switch r.Method {
+	case http.MethodGet:
+		targets := r.URL.Query().Get("targets")
+
+		connections := []interface{}{}
+		for _, a := range s.associations[key] {
+			if targets == "" || a.To.Type == targets {
+				connections = append(connections, a)
+			}
+		}
+
+		writeJSON(w, http.StatusOK, paginate(r, connections))
+
+	case http.MethodPost:
+		var operation graphOperation
+		if err := json.NewDecoder(r.Body).Decode(&operation); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+			return
+		}
+
+		if operation.Type == "" || operation.Id == "" {
+			writeError(w, http.StatusBadRequest, "type and id are required")
+			return
+		}
+
+		index := -1
+		for i, a := range s.associations[key] {
+			if a.To.Id == operation.Id && a.To.Type == operation.Type {
+				index = i
+			}
+		}
+
+		switch operation.Op {
+		case "add":
+			if index >= 0 {
+				writeError(w, http.StatusConflict, "Conflict: already associated")
+				return
+			}
+			s.associations[key] = append(s.associations[key], association{
+				To:         member{Id: operation.Id, Type: operation.Type},
+				Attributes: operation.Attributes,
+			})
+		case "update":
+			if index < 0 {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			s.associations[key][index].Attributes = operation.Attributes
+		case "remove":
+			if index < 0 {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			s.associations[key] = append(s.associations[key][:index], s.associations[key][index+1:]...)
+		default:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported op %q", operation.Op))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// paginate applies the skip and limit of a graph listing
+func paginate(r *http.Request, connections []interface{}) []interface{} {
+	query := r.URL.Query()
+	skip, _ := strconv.Atoi(query.Get("skip"))
+	if skip > len(connections) {
+		skip = len(connections)
+	}
+	connections = connections[skip:]
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit < len(connections) {
+		connections = connections[:limit]
+	}
+
+	return connections
+}
+
+// validate returns the message of the error JumpCloud would answer with, or ""
+func (s *Server) validate(c *collection, object Object, id string) string {
+	for _, field := range c.required {
+		if value, ok := object[field].(string); !ok || value == "" {
+			return fmt.Sprintf("%s is required", field)
+		}
+	}
+
+	if c.unique != "" {
+		for otherId, other := range c.objects {
+			if otherId != id && other[c.unique] == object[c.unique] {
+				return fmt.Sprintf("Conflict: %s %q already exists", c.unique, object[c.unique])
+			}
+		}
+	}
+
+	return ""
+}
+
+func (s *Server) insert(c *collection, object Object) Object {
+	s.lastId++
+	id := fmt.Sprintf("5fa0%020x", s.lastId)
+
+	object[c.idField()] = id
+	c.objects[id] = object
+	c.order = append(c.order, id)
+
+	return object
+}
+
+func (s *Server) remove(c *collection, name string, id string) {
+	delete(c.objects, id)
+	delete(s.members, name+"/"+id)
+	delete(s.associations, name+"/"+id)
+
+	for i, orderId := range c.order {
+		if orderId == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *collection) idField() string {
+	if c.v1 {
+		return "_id"
+	}
+	return "id"
+}
+
+// matchesFilters applies filters of the form field:op:value, where v1 writes the
+// operator as $eq
+func matchesFilters(object Object, filters []string) bool {
+	for _, filter := range filters {
+		parts := strings.SplitN(filter, ":", 3)
+		if len(parts) != 3 {
+			return false
+		}
+
+		value := fmt.Sprint(object[parts[0]])
+		if object[parts[0]] == nil {
+			value = ""
+		}
+
+		switch strings.TrimPrefix(parts[1], "$") {
+		case "eq":
+			if value != parts[2] {
+				return false
+			}
+		case "ne":
+			if value == parts[2] {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortObjects orders by a comma (v2) or space (v1) separated list of fields, each
+// optionally prefixed with -
+func sortObjects(objects []Object, fields string) {
+	keys := strings.FieldsFunc(fields, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		for _, key := range keys {
+			descending := strings.HasPrefix(key, "-")
+			key = strings.TrimPrefix(key, "-")
+
+			a, b := fmt.Sprint(objects[i][key]), fmt.Sprint(objects[j][key])
+			if a == b {
+				continue
+			}
+
+			return (a < b) != descending
+		}
+
+		return false
+	})
+}
+
+func decodeObject(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	var object Object
+	if err := json.NewDecoder(r.Body).Decode(&object); err != nil || object == nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return nil, false
+	}
+
+	return object, true
+}
+
+func copyObject(object Object) Object {
+	if object == nil {
+		return nil
+	}
+
+	// Round trip through JSON so nested maps and slices are not shared
+	data, _ := json.Marshal(object)
+
+	var copied Object
+	json.Unmarshal(data, &copied)
+
+	return copied
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message": message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
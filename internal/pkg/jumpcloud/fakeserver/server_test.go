@@ -0,0 +1,170 @@
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/techjavelin/terraform-provider-jumpcloud/internal/pkg/jumpcloud/apiclient"
+)
+
+func newTestClient(s *Server) apiclient.Client {
+	return apiclient.New(context.Background(), ApiKey, "test", apiclient.WithBaseUrl(s.BaseURL()), apiclient.WithRetry(0, 1))
+}
+
+func TestUserGroupLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(s)
+
+	created, _, err := client.CreateUserGroup(context.Background(), &apiclient.UserGroup{Name: "engineering"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if created.Id == "" || created.Name != "engineering" {
+		t.Fatalf("Unexpected group %#v", created)
+	}
+
+	created.Description = "Engineers"
+	if _, _, err := client.UpdateUserGroup(context.Background(), &created); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	read, _, err := client.GetUserGroupDetails(context.Background(), created.Id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if read.Description != "Engineers" {
+		t.Errorf("Expected the update to be stored but got %#v", read)
+	}
+
+	if _, err := client.DeleteUserGroup(context.Background(), created.Id); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, _, err := client.GetUserGroupDetails(context.Background(), created.Id); !apiclient.IsNotFound(err) {
+		t.Errorf("Expected a 404 after delete but got %v", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(s)
+
+	if _, _, err := client.CreateUserGroup(context.Background(), &apiclient.UserGroup{}); err == nil || apiclient.IsConflict(err) {
+		t.Errorf("Expected a 400 for a group without name but got %v", err)
+	}
+
+	s.Seed(UserGroups, Object{"name": "taken"})
+
+	if _, _, err := client.CreateUserGroup(context.Background(), &apiclient.UserGroup{Name: "taken"}); !apiclient.IsConflict(err) {
+		t.Errorf("Expected a 409 for a duplicate name but got %v", err)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := apiclient.New(context.Background(), "wrong", "test", apiclient.WithBaseUrl(s.BaseURL()))
+
+	_, _, err := client.GetUserGroupDetails(context.Background(), "5fa000000000000000000001")
+	if err == nil || apiclient.IsNotFound(err) {
+		t.Errorf("Expected a 401 but got %v", err)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(s)
+	group := s.Seed(SystemGroups, Object{"name": "servers"})
+	groupId := group["id"].(string)
+
+	if _, err := client.AddSystemGroupMember(context.Background(), groupId, "system-1"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	members, err := client.ListSystemGroupMembers(context.Background(), groupId)
+	if err != nil || len(members) != 1 || members[0] != "system-1" {
+		t.Errorf("Expected [system-1] but got %v (%v)", members, err)
+	}
+
+	if _, err := client.RemoveSystemGroupMember(context.Background(), groupId, "system-1"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if members := s.Members(SystemGroups, groupId); len(members) != 0 {
+		t.Errorf("Expected no members but got %v", members)
+	}
+
+	if _, err := client.ListSystemGroupMembers(context.Background(), "5fa000000000000000000999"); !apiclient.IsNotFound(err) {
+		t.Errorf("Expected a 404 for an unknown group but got %v", err)
+	}
+}
+
+func TestListFilterSortAndPaging(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	for _, name := range []string{"b", "c", "a"} {
+		s.Seed(UserGroups, Object{"name": name})
+	}
+
+	get := func(query string) (names []string, total string) {
+		request, _ := http.NewRequest(http.MethodGet, s.BaseURL()+"/v2/usergroups?"+query, nil)
+		request.Header.Set("x-api-key", ApiKey)
+
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer response.Body.Close()
+
+		var groups []Object
+		json.NewDecoder(response.Body).Decode(&groups)
+		for _, group := range groups {
+			names = append(names, group["name"].(string))
+		}
+
+		return names, response.Header.Get("x-total-count")
+	}
+
+	if names, total := get("sort=-name&limit=2&skip=1"); len(names) != 2 || names[0] != "b" || names[1] != "a" || total != "3" {
+		t.Errorf("Expected [b a] of 3 but got %v of %s", names, total)
+	}
+
+	if names, _ := get("filter=name:eq:c"); len(names) != 1 || names[0] != "c" {
+		t.Errorf("Expected [c] but got %v", names)
+	}
+}
+
+func TestV1SystemUsers(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client := newTestClient(s)
+
+	created, _, err := client.CreateUser(context.Background(), &apiclient.User{Username: "jdoe", Email: "jdoe@example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	found, _, err := client.GetUserByUsername(context.Background(), "jdoe")
+	if err != nil || found.Id != created.Id {
+		t.Errorf("Expected to find %s but got %#v (%v)", created.Id, found, err)
+	}
+
+	s.Remove(SystemUsers, created.Id)
+
+	if _, err := client.DeleteUser(context.Background(), created.Id); !apiclient.IsNotFound(err) {
+		t.Errorf("Expected a 404 for a removed user but got %v", err)
+	}
+}
@@ -22,12 +22,14 @@ func (m BoolDefaultModifier) MarkdownDescription(ctx context.Context) string {
 }
 
 func (m BoolDefaultModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
-	if !req.AttributePlan.IsNull() {
+	// Computed attributes are planned as unknown rather than null when they are
+	// not configured, so the configuration is what decides whether to default
+	if !req.AttributeConfig.IsNull() {
 		return
 	}
 
 	var boolval types.Bool
-	diags := tfsdk.ValueAs(ctx, req.AttributePlan, boolval)
+	diags := tfsdk.ValueAs(ctx, req.AttributePlan, &boolval)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
@@ -0,0 +1,40 @@
+package planmodifiers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// StringConfigDefaultModifier plans Default whenever the attribute is not configured.
+// Unlike StringDefaultModifier it also applies to optional and computed attributes, which
+// are planned as unknown rather than null, so a value changed outside of Terraform is
+// reset to Default on the next apply.
+type StringConfigDefaultModifier struct {
+	Default string
+}
+
+func (m StringConfigDefaultModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("If value is not configured, defaults to %s", m.Default)
+}
+
+func (m StringConfigDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("If value is not configured, defaults to %s", m.Default)
+}
+
+func (m StringConfigDefaultModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	if !req.AttributeConfig.IsNull() {
+		return
+	}
+
+	var str types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributePlan, &str)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.AttributePlan = types.StringValue(m.Default)
+}
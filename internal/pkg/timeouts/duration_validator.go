@@ -0,0 +1,43 @@
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DurationValidator checks that a string attribute is a positive Go duration, eg "90s" or "1h30m"
+type DurationValidator struct{}
+
+func (v DurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30s, 10m or 1h"
+}
+
+func (v DurationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration such as `30s`, `10m` or `1h`"
+}
+
+func (v DurationValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("must be greater than zero")
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Timeout",
+			fmt.Sprintf("%q is not a valid duration: %s", value.ValueString(), err),
+		)
+	}
+}
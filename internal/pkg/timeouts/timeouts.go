@@ -0,0 +1,95 @@
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	CREATE = "create"
+	READ   = "read"
+	UPDATE = "update"
+	DELETE = "delete"
+
+	// A single API call may be retried for up to apiclient.DEFAULT_MAX_RETRIES times
+	// with waits of up to apiclient.DEFAULT_RETRY_MAX_WAIT, about two and a half
+	// minutes in total, so the default leaves room for a fully backed off call
+	DEFAULT_TIMEOUT = 5 * time.Minute
+)
+
+// Attribute is the optional `timeouts` attribute added to resource schemas, it
+// holds a duration string (eg "30s" or "10m") for each operation
+func Attribute() tfsdk.Attribute {
+	attributes := map[string]tfsdk.Attribute{}
+
+	for _, operation := range []string{CREATE, READ, UPDATE, DELETE} {
+		attributes[operation] = tfsdk.Attribute{
+			MarkdownDescription: fmt.Sprintf("How long to wait for the %s to finish, eg `30s` or `10m`. Defaults to `%s`.", operation, formatDuration(DEFAULT_TIMEOUT)),
+			Type:                types.StringType,
+			Optional:            true,
+			Validators: []tfsdk.AttributeValidator{
+				DurationValidator{},
+			},
+		}
+	}
+
+	return tfsdk.Attribute{
+		MarkdownDescription: "Timeouts for the operations on this resource, API calls still in flight when they expire are cancelled",
+		Attributes:          tfsdk.SingleNestedAttributes(attributes),
+		Optional:            true,
+	}
+}
+
+func AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		CREATE: types.StringType,
+		READ:   types.StringType,
+		UPDATE: types.StringType,
+		DELETE: types.StringType,
+	}
+}
+
+// Null is the value of an unconfigured `timeouts` attribute
+func Null() types.Object {
+	return types.ObjectNull(AttrTypes())
+}
+
+// Timeout returns the configured timeout of operation, or DEFAULT_TIMEOUT when it
+// is not set. Invalid durations are rejected by DurationValidator before any
+// operation runs, so they fall back to the default here.
+func Timeout(timeouts types.Object, operation string) time.Duration {
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return DEFAULT_TIMEOUT
+	}
+
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return DEFAULT_TIMEOUT
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		return DEFAULT_TIMEOUT
+	}
+
+	return timeout
+}
+
+// WithTimeout returns a copy of ctx that is cancelled once the timeout of operation
+// has passed, cancelling any API call still in flight
+func WithTimeout(ctx context.Context, timeouts types.Object, operation string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, Timeout(timeouts, operation))
+}
+
+func formatDuration(d time.Duration) string {
+	if d%time.Minute == 0 {
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+
+	return d.String()
+}
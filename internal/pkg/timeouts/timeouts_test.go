@@ -0,0 +1,68 @@
+package timeouts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeout(t *testing.T) {
+	configured := types.ObjectValueMust(AttrTypes(), map[string]attr.Value{
+		CREATE: types.StringValue("90s"),
+		READ:   types.StringNull(),
+		UPDATE: types.StringValue("1h"),
+		DELETE: types.StringValue("-1m"),
+	})
+
+	cases := []struct {
+		name      string
+		timeouts  types.Object
+		operation string
+		expected  time.Duration
+	}{
+		{"null", Null(), CREATE, DEFAULT_TIMEOUT},
+		{"unknown", types.ObjectUnknown(AttrTypes()), UPDATE, DEFAULT_TIMEOUT},
+		{"configured", configured, CREATE, 90 * time.Second},
+		{"other operation configured", configured, UPDATE, time.Hour},
+		{"operation not configured", configured, READ, DEFAULT_TIMEOUT},
+		{"not positive", configured, DELETE, DEFAULT_TIMEOUT},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Timeout(c.timeouts, c.operation); got != c.expected {
+				t.Errorf("Expected %s but got %s", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	cases := map[string]bool{
+		"30s":   true,
+		"1h30m": true,
+		"10":    false,
+		"0s":    false,
+		"-5m":   false,
+		"soon":  false,
+	}
+
+	for value, valid := range cases {
+		t.Run(value, func(t *testing.T) {
+			resp := tfsdk.ValidateAttributeResponse{}
+			DurationValidator{}.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+				AttributePath:   path.Root("timeouts").AtName(CREATE),
+				AttributeConfig: types.StringValue(value),
+			}, &resp)
+
+			if resp.Diagnostics.HasError() == valid {
+				t.Errorf("Expected %q to be valid: %t, got %v", value, valid, resp.Diagnostics)
+			}
+		})
+	}
+}